@@ -1,7 +1,12 @@
 package httpexpect
 
 import (
-	"reflect"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
 )
 
 // Array provides methods to inspect attached []interface{} object
@@ -44,9 +49,14 @@ func (a *Array) Path(path string) *Value {
 	return getPath(&a.chain, a.value, path)
 }
 
+// JSONPath is similar to Value.JSONPath.
+func (a *Array) JSONPath(expr string) *Value {
+	return getJSONPath(&a.chain, a.value, expr)
+}
+
 // Schema is similar to Value.Schema.
 func (a *Array) Schema(schema interface{}) *Array {
-	checkSchema(&a.chain, a.value, schema)
+	checkSchema(&a.chain, "array", a.value, schema)
 	return a
 }
 
@@ -56,12 +66,15 @@ func (a *Array) Schema(schema interface{}) *Array {
 //  array := NewArray(t, []interface{}{1, 2, 3})
 //  array.Length().Equal(3)
 func (a *Array) Length() *Number {
-	return &Number{a.chain, float64(len(a.value))}
+	return &Number{a.chain.clone(), float64(len(a.value)), nil}
 }
 
 // Element returns a new Value object that may be used to inspect array element
 // for given index.
 //
+// Negative index counts from the end of array, so -1 refers to the last
+// element, -2 to the one before it, and so on.
+//
 // If index is out of array bounds, Element reports failure and returns empty
 // (but non-nil) value.
 //
@@ -69,16 +82,20 @@ func (a *Array) Length() *Number {
 //  array := NewArray(t, []interface{}{"foo", 123})
 //  array.Element(0).String().Equal("foo")
 //  array.Element(1).Number().Equal(123)
+//  array.Element(-1).Number().Equal(123)
 func (a *Array) Element(index int) *Value {
+	if index < 0 && index >= -len(a.value) {
+		index += len(a.value)
+	}
 	if index < 0 || index >= len(a.value) {
 		a.chain.fail(
 			"\narray index out of bounds:\n  index %d\n\n  bounds [%d; %d)",
 			index,
 			0,
 			len(a.value))
-		return &Value{a.chain, nil}
+		return &Value{a.chain.clone(), nil}
 	}
-	return &Value{a.chain, a.value[index]}
+	return &Value{a.chain.child(indexPath(index)), a.value[index]}
 }
 
 // First returns a new Value object that may be used to inspect first element
@@ -93,9 +110,9 @@ func (a *Array) Element(index int) *Value {
 func (a *Array) First() *Value {
 	if len(a.value) < 1 {
 		a.chain.fail("\narray is empty")
-		return &Value{a.chain, nil}
+		return &Value{a.chain.clone(), nil}
 	}
-	return &Value{a.chain, a.value[0]}
+	return &Value{a.chain.child(indexPath(0)), a.value[0]}
 }
 
 // Last returns a new Value object that may be used to inspect last element
@@ -110,9 +127,10 @@ func (a *Array) First() *Value {
 func (a *Array) Last() *Value {
 	if len(a.value) < 1 {
 		a.chain.fail("\narray is empty")
-		return &Value{a.chain, nil}
+		return &Value{a.chain.clone(), nil}
 	}
-	return &Value{a.chain, a.value[len(a.value)-1]}
+	index := len(a.value) - 1
+	return &Value{a.chain.child(indexPath(index)), a.value[index]}
 }
 
 // Iter returns a new slice of Values attached to array elements.
@@ -130,11 +148,211 @@ func (a *Array) Iter() []Value {
 	}
 	ret := []Value{}
 	for n := range a.value {
-		ret = append(ret, Value{a.chain, a.value[n]})
+		ret = append(ret, Value{a.chain.child(indexPath(n)), a.value[n]})
+	}
+	return ret
+}
+
+// AsObjects returns a new slice of Objects attached to array elements.
+//
+// If some array element is not an object, AsObjects reports failure and
+// returns empty slice.
+//
+// Example:
+//  array := NewArray(t, []interface{}{
+//      map[string]interface{}{"name": "john"},
+//      map[string]interface{}{"name": "bob"},
+//  })
+//
+//  for _, obj := range array.AsObjects() {
+//      obj.ContainsKey("name")
+//  }
+func (a *Array) AsObjects() []*Object {
+	if a.chain.failed() {
+		return []*Object{}
 	}
+
+	ret := []*Object{}
+	errors := ""
+	for n, e := range a.value {
+		obj, ok := e.(map[string]interface{})
+		if !ok {
+			errors += fmt.Sprintf(" [%d]: expected object, but got:\n%s\n",
+				n, dumpValue(e))
+			continue
+		}
+		ret = append(ret, &Object{a.chain.child(indexPath(n)), obj})
+	}
+
+	if errors != "" {
+		a.chain.failWith(failure{
+			assertType: "array",
+			assertion:  "expected array of objects",
+			actual:     dumpValue(a.value),
+			details:    "\n\nerrors:\n" + strings.TrimSuffix(errors, "\n"),
+		})
+		return []*Object{}
+	}
+
 	return ret
 }
 
+// Every runs given function for every array element, in ascending index order.
+//
+// The function is given element index and a new Value object attached to the
+// element. Failures reported while inspecting the element are reported
+// as usual, and mark the array as failed as well.
+//
+// Example:
+//  array := NewArray(t, []interface{}{"foo", "bar"})
+//
+//  array.Every(func(index int, value *httpexpect.Value) {
+//      value.String().NotEmpty()
+//  })
+func (a *Array) Every(fn func(index int, value *Value)) *Array {
+	if a.chain.failed() {
+		return a
+	}
+	for n, e := range a.value {
+		v := &Value{a.chain.child(indexPath(n)), e}
+		fn(n, v)
+		if v.chain.failed() {
+			a.chain.setFailed()
+		}
+	}
+	return a
+}
+
+// Some succeeds if given predicate returns true for at least one array
+// element. Elements are visited in ascending index order.
+//
+// The predicate is given element index and a new Value object attached to
+// the element. Failures occurred inside predicate are not reported; instead,
+// the element is treated as not satisfying the predicate.
+//
+// Example:
+//  array := NewArray(t, []interface{}{"foo", 123})
+//
+//  array.Some(func(index int, value *httpexpect.Value) bool {
+//      return value.String().Raw() == "foo"
+//  })
+func (a *Array) Some(fn func(index int, value *Value) bool) *Array {
+	if a.chain.failed() {
+		return a
+	}
+	for n, e := range a.value {
+		v := &Value{makeChain(discardReporter{}), e}
+		if fn(n, v) && !v.chain.failed() {
+			return a
+		}
+	}
+	a.chain.failWith(failure{
+		assertType: "array",
+		assertion:  "expected array containing element satisfying predicate",
+		actual:     dumpValue(a.value),
+	})
+	return a
+}
+
+// Map returns a new Array with results of given function applied to every
+// array element, in ascending index order.
+//
+// The function is given element index and a new Value object attached to the
+// element, and should return transformed value. It should be a pure
+// projection: failures occurred inside the function are not reported as is;
+// instead, Map reports that element couldn't be transformed. Returned values
+// are converted to canonical form.
+//
+// Example:
+//  array := NewArray(t, []interface{}{
+//      map[string]interface{}{"id": 1},
+//      map[string]interface{}{"id": 2},
+//  })
+//
+//  array.Map(func(index int, value *httpexpect.Value) interface{} {
+//      return value.Object().Value("id").Raw()
+//  }).ContainsOnly(1, 2)
+func (a *Array) Map(fn func(index int, value *Value) interface{}) *Array {
+	if a.chain.failed() {
+		return &Array{a.chain.clone(), nil}
+	}
+	result := make([]interface{}, 0, len(a.value))
+	for n, e := range a.value {
+		reporter := &countingReporter{}
+		chain := makeChain(reporter)
+		chain.preserveNumbers = a.chain.preserveNumbers
+		chain.comparator = a.chain.comparator
+		v := &Value{chain, e}
+		mapped := fn(n, v)
+		if reporter.count != 0 {
+			a.chain.failWith(failure{
+				assertType: "array",
+				assertion: fmt.Sprintf(
+					"expected successful transformation of array element [%d]", n),
+				actual: dumpValue(e),
+			})
+			return &Array{a.chain.clone(), nil}
+		}
+		result = append(result, mapped)
+	}
+	canon, ok := canonArray(&a.chain, result)
+	if !ok {
+		return &Array{a.chain.clone(), nil}
+	}
+	return &Array{a.chain.clone(), canon}
+}
+
+// Reduce applies given function to accumulator and every array element, in
+// ascending index order, and returns the final accumulator value.
+//
+// The function is given current accumulator, starting with initial, and
+// a new Value object attached to the element, and should return updated
+// accumulator. Like in Map, failures occurred inside the function are not
+// reported as is; instead, Reduce reports that element couldn't be reduced
+// and returns initial. If array chain is already failed, initial is
+// returned as well.
+//
+// Since the result may be of any type, it's returned as is. Typically it's
+// passed to NewNumber, NewString, or similar for further assertions.
+//
+// Example:
+//  array := NewArray(t, []interface{}{
+//      map[string]interface{}{"price": 10},
+//      map[string]interface{}{"price": 20},
+//  })
+//
+//  total := array.Reduce(0.0,
+//      func(acc interface{}, value *httpexpect.Value) interface{} {
+//          return acc.(float64) + value.Object().Value("price").Number().Raw()
+//      })
+//
+//  httpexpect.NewNumber(t, total.(float64)).Equal(30)
+func (a *Array) Reduce(
+	initial interface{}, fn func(acc interface{}, value *Value) interface{},
+) interface{} {
+	if a.chain.failed() {
+		return initial
+	}
+	acc := initial
+	for n, e := range a.value {
+		reporter := &countingReporter{}
+		chain := makeChain(reporter)
+		chain.preserveNumbers = a.chain.preserveNumbers
+		chain.comparator = a.chain.comparator
+		acc = fn(acc, &Value{chain, e})
+		if reporter.count != 0 {
+			a.chain.failWith(failure{
+				assertType: "array",
+				assertion: fmt.Sprintf(
+					"expected successful reduction of array element [%d]", n),
+				actual: dumpValue(e),
+			})
+			return initial
+		}
+	}
+	return acc
+}
+
 // Empty succeeds if array is empty.
 //
 // Example:
@@ -172,11 +390,15 @@ func (a *Array) Equal(value interface{}) *Array {
 	if !ok {
 		return a
 	}
-	if !reflect.DeepEqual(expected, a.value) {
-		a.chain.fail("\nexpected array equal to:\n%s\n\nbut got:\n%s\n\ndiff:\n%s",
-			dumpValue(expected),
-			dumpValue(a.value),
-			diffValues(expected, a.value))
+	markTimes(value, expected)
+	if !a.chain.equal(expected, a.value) {
+		a.chain.failWith(failure{
+			assertType: "array",
+			assertion:  "expected array equal to",
+			expected:   dumpValue(expected),
+			actual:     dumpValue(a.value),
+			details:    "\n\ndiff:\n" + diffValues(expected, a.value),
+		})
 	}
 	return a
 }
@@ -194,9 +416,13 @@ func (a *Array) NotEqual(value interface{}) *Array {
 	if !ok {
 		return a
 	}
-	if reflect.DeepEqual(expected, a.value) {
-		a.chain.fail("\nexpected array not equal to:\n%s",
-			dumpValue(expected))
+	markTimes(value, expected)
+	if a.chain.equal(expected, a.value) {
+		a.chain.failWith(failure{
+			assertType: "array",
+			assertion:  "expected array not equal to",
+			expected:   dumpValue(expected),
+		})
 	}
 	return a
 }
@@ -228,10 +454,15 @@ func (a *Array) Contains(values ...interface{}) *Array {
 	if !ok {
 		return a
 	}
+	markTimes(values, elements)
 	for _, e := range elements {
 		if !a.containsElement(e) {
-			a.chain.fail("\nexpected array containing element:\n%s\n\nbut got:\n%s",
-				dumpValue(e), dumpValue(a.value))
+			a.chain.failWith(failure{
+				assertType: "array",
+				assertion:  "expected array containing element",
+				expected:   dumpValue(e),
+				actual:     dumpValue(a.value),
+			})
 		}
 	}
 	return a
@@ -249,10 +480,15 @@ func (a *Array) NotContains(values ...interface{}) *Array {
 	if !ok {
 		return a
 	}
+	markTimes(values, elements)
 	for _, e := range elements {
 		if a.containsElement(e) {
-			a.chain.fail("\nexpected array not containing element:\n%s\n\nbut got:\n%s",
-				dumpValue(e), dumpValue(a.value))
+			a.chain.failWith(failure{
+				assertType: "array",
+				assertion:  "expected array not containing element",
+				expected:   dumpValue(e),
+				actual:     dumpValue(a.value),
+			})
 		}
 	}
 	return a
@@ -273,25 +509,436 @@ func (a *Array) ContainsOnly(values ...interface{}) *Array {
 	if !ok {
 		return a
 	}
+	markTimes(values, elements)
 	if len(elements) != len(a.value) {
-		a.chain.fail("\nexpected array of length == %d:\n%s\n\n"+
-			"but got array of length %d:\n%s",
-			len(elements), dumpValue(elements),
-			len(a.value), dumpValue(a.value))
+		a.chain.failWith(failure{
+			assertType: "array",
+			assertion:  fmt.Sprintf("expected array of length == %d", len(elements)),
+			expected:   dumpValue(elements),
+			actual:     dumpValue(a.value),
+			got:        fmt.Sprintf("but got array of length %d", len(a.value)),
+		})
 		return a
 	}
 	for _, e := range elements {
 		if !a.containsElement(e) {
-			a.chain.fail("\nexpected array containing element:\n%s\n\nbut got:\n%s",
-				dumpValue(e), dumpValue(a.value))
+			a.chain.failWith(failure{
+				assertType: "array",
+				assertion:  "expected array containing element",
+				expected:   dumpValue(e),
+				actual:     dumpValue(a.value),
+			})
 		}
 	}
 	return a
 }
 
+// ContainsAll succeeds if array contains all given elements, in any order.
+// Array may contain other elements as well. Before comparison, array and all
+// elements are converted to canonical form.
+//
+// On failure, all missing elements are reported.
+//
+// Example:
+//  array := NewArray(t, []interface{}{"foo", 123, "bar"})
+//  array.ContainsAll(123, "foo")
+func (a *Array) ContainsAll(values ...interface{}) *Array {
+	if values == nil {
+		values = []interface{}{}
+	}
+	elements, ok := canonArray(&a.chain, values)
+	if !ok {
+		return a
+	}
+	markTimes(values, elements)
+	missing := []interface{}{}
+	for _, e := range elements {
+		if !a.containsElement(e) {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) != 0 {
+		a.chain.failWith(failure{
+			assertType: "array",
+			assertion:  "expected array containing all elements",
+			expected:   dumpValue(elements),
+			actual:     dumpValue(a.value),
+			details:    "\n\nmissing elements:\n" + dumpValue(missing),
+		})
+	}
+	return a
+}
+
+// ContainsAny succeeds if array contains at least one of given elements.
+// Before comparison, array and all elements are converted to canonical form.
+//
+// Example:
+//  array := NewArray(t, []interface{}{"foo", 123})
+//  array.ContainsAny(123, "bar")
+func (a *Array) ContainsAny(values ...interface{}) *Array {
+	if values == nil {
+		values = []interface{}{}
+	}
+	elements, ok := canonArray(&a.chain, values)
+	if !ok {
+		return a
+	}
+	markTimes(values, elements)
+	for _, e := range elements {
+		if a.containsElement(e) {
+			return a
+		}
+	}
+	a.chain.failWith(failure{
+		assertType: "array",
+		assertion:  "expected array containing any of elements",
+		expected:   dumpValue(elements),
+		actual:     dumpValue(a.value),
+	})
+	return a
+}
+
+// ContainsExactlyOnce succeeds if every given element occurs in array exactly
+// once. Array may contain other elements as well. Before comparison, array
+// and all elements are converted to canonical form.
+//
+// On failure, number of occurrences of every given element is reported.
+//
+// Example:
+//  array := NewArray(t, []interface{}{"foo", 123, "bar", "bar"})
+//  array.ContainsExactlyOnce("foo", 123)  // success
+//  array.ContainsExactlyOnce("foo", "bar")  // failure ("bar" occurs twice)
+func (a *Array) ContainsExactlyOnce(values ...interface{}) *Array {
+	if values == nil {
+		values = []interface{}{}
+	}
+	elements, ok := canonArray(&a.chain, values)
+	if !ok {
+		return a
+	}
+	markTimes(values, elements)
+	failed := false
+	counts := ""
+	for _, e := range elements {
+		n := 0
+		for _, v := range a.value {
+			if a.chain.equal(e, v) {
+				n++
+			}
+		}
+		if n != 1 {
+			failed = true
+		}
+		b, _ := json.Marshal(e)
+		counts += fmt.Sprintf(" %s: %d\n", b, n)
+	}
+	if failed {
+		a.chain.failWith(failure{
+			assertType: "array",
+			assertion:  "expected array containing every element exactly once",
+			expected:   dumpValue(elements),
+			actual:     dumpValue(a.value),
+			details:    "\n\ncounts:\n" + strings.TrimSuffix(counts, "\n"),
+		})
+	}
+	return a
+}
+
+// Distinct succeeds if array has no duplicate elements. Elements are
+// compared in canonical form.
+//
+// On failure, the first duplicated element is reported.
+//
+// Example:
+//  array := NewArray(t, []interface{}{"foo", 123, "bar"})
+//  array.Distinct()
+func (a *Array) Distinct() *Array {
+	if a.chain.failed() {
+		return a
+	}
+	if i, j, ok := a.findDuplicate(); ok {
+		a.chain.failWith(failure{
+			assertType: "array",
+			assertion:  "expected array with distinct elements",
+			actual:     dumpValue(a.value),
+			details: fmt.Sprintf("\n\nelements [%d] and [%d] are equal:\n%s",
+				i, j, dumpValue(a.value[i])),
+		})
+	}
+	return a
+}
+
+// NotDistinct succeeds if array has at least one duplicate element.
+// Elements are compared in canonical form.
+//
+// Example:
+//  array := NewArray(t, []interface{}{"foo", 123, "foo"})
+//  array.NotDistinct()
+func (a *Array) NotDistinct() *Array {
+	if a.chain.failed() {
+		return a
+	}
+	if _, _, ok := a.findDuplicate(); !ok {
+		a.chain.failWith(failure{
+			assertType: "array",
+			assertion:  "expected array with duplicate elements",
+			actual:     dumpValue(a.value),
+		})
+	}
+	return a
+}
+
+func (a *Array) findDuplicate() (int, int, bool) {
+	for j := range a.value {
+		for i := 0; i < j; i++ {
+			if a.chain.equal(a.value[i], a.value[j]) {
+				return i, j, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// IsOrdered succeeds if array elements are sorted in non-decreasing order.
+//
+// If less function is not given, elements are compared using default
+// comparator, which supports arrays of numbers and arrays of strings.
+// If array contains elements of other types, or both numbers and strings,
+// failure is reported. Otherwise, given less function is used to compare
+// adjacent elements.
+//
+// On failure, the first pair of elements out of order is reported.
+//
+// Example:
+//  array := NewArray(t, []interface{}{1, 2, 2, 3})
+//  array.IsOrdered()
+//
+//  array := NewArray(t, []interface{}{
+//      map[string]interface{}{"id": 1},
+//      map[string]interface{}{"id": 2},
+//  })
+//  array.IsOrdered(func(a, b *Value) bool {
+//      return a.Object().Value("id").Number().Raw() <
+//          b.Object().Value("id").Number().Raw()
+//  })
+func (a *Array) IsOrdered(less ...func(a, b *Value) bool) *Array {
+	if len(less) > 1 {
+		a.chain.fail("\nunexpected multiple less arguments passed to IsOrdered")
+		return a
+	}
+	a.checkOrdered(false, less)
+	return a
+}
+
+// IsOrderedReverse succeeds if array elements are sorted in non-increasing
+// order.
+//
+// less function is handled in the same way as in IsOrdered.
+//
+// Example:
+//  array := NewArray(t, []interface{}{"c", "b", "b", "a"})
+//  array.IsOrderedReverse()
+func (a *Array) IsOrderedReverse(less ...func(a, b *Value) bool) *Array {
+	if len(less) > 1 {
+		a.chain.fail(
+			"\nunexpected multiple less arguments passed to IsOrderedReverse")
+		return a
+	}
+	a.checkOrdered(true, less)
+	return a
+}
+
+func (a *Array) checkOrdered(reverse bool, less []func(a, b *Value) bool) {
+	if a.chain.failed() {
+		return
+	}
+
+	var fn func(x, y interface{}) bool
+	if len(less) == 1 {
+		fn = func(x, y interface{}) bool {
+			return less[0](&Value{a.chain.clone(), x}, &Value{a.chain.clone(), y})
+		}
+	} else {
+		var ok bool
+		if fn, ok = defaultLess(a.value); !ok {
+			a.chain.failWith(failure{
+				assertType: "array",
+				assertion:  "expected array of numbers or array of strings",
+				actual:     dumpValue(a.value),
+			})
+			return
+		}
+	}
+
+	for n := 1; n < len(a.value); n++ {
+		prev, cur := a.value[n-1], a.value[n]
+		if reverse {
+			prev, cur = cur, prev
+		}
+		if fn(cur, prev) {
+			order := "ascending"
+			if reverse {
+				order = "descending"
+			}
+			a.chain.failWith(failure{
+				assertType: "array",
+				assertion:  "expected array sorted in " + order + " order",
+				actual:     dumpValue(a.value),
+				details: fmt.Sprintf(
+					"\n\nelements [%d] and [%d] are out of order:\n%s\n%s",
+					n-1, n, dumpValue(a.value[n-1]), dumpValue(a.value[n])),
+			})
+			return
+		}
+	}
+}
+
+func defaultLess(values []interface{}) (func(x, y interface{}) bool, bool) {
+	if len(values) == 0 {
+		return func(x, y interface{}) bool { return false }, true
+	}
+
+	switch values[0].(type) {
+	case float64, json.Number:
+		for _, v := range values {
+			if _, ok := numberFloat(v); !ok {
+				return nil, false
+			}
+		}
+		return numberLess, true
+
+	case string:
+		for _, v := range values {
+			if _, ok := v.(string); !ok {
+				return nil, false
+			}
+		}
+		return func(x, y interface{}) bool {
+			return x.(string) < y.(string)
+		}, true
+	}
+
+	return nil, false
+}
+
+func numberLess(x, y interface{}) bool {
+	xf, xok := x.(float64)
+	yf, yok := y.(float64)
+	if xok && yok {
+		return xf < yf
+	}
+	return numberRat(x).Cmp(numberRat(y)) < 0
+}
+
+func numberRat(value interface{}) *big.Rat {
+	if number, ok := value.(json.Number); ok {
+		if r, ok := new(big.Rat).SetString(string(number)); ok {
+			return r
+		}
+	}
+	r := new(big.Rat)
+	if f, ok := numberFloat(value); ok && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		r.SetFloat64(f)
+	}
+	return r
+}
+
+// EveryObjectHasKeys succeeds if every array element is an object containing
+// all given keys. Objects may contain other keys as well.
+//
+// On failure, every offending element is reported with its index and reason.
+//
+// Example:
+//  array := NewArray(t, []interface{}{
+//      map[string]interface{}{"id": 1, "name": "foo"},
+//      map[string]interface{}{"id": 2, "name": "bar", "extra": true},
+//  })
+//  array.EveryObjectHasKeys("id", "name")
+func (a *Array) EveryObjectHasKeys(keys ...string) *Array {
+	a.checkEveryObjectKeys(keys, false)
+	return a
+}
+
+// EveryObjectHasOnlyKeys succeeds if every array element is an object containing
+// all given keys and only them.
+//
+// On failure, every offending element is reported with its index and reason.
+//
+// Example:
+//  array := NewArray(t, []interface{}{
+//      map[string]interface{}{"id": 1, "name": "foo"},
+//      map[string]interface{}{"id": 2, "name": "bar"},
+//  })
+//  array.EveryObjectHasOnlyKeys("id", "name")
+func (a *Array) EveryObjectHasOnlyKeys(keys ...string) *Array {
+	a.checkEveryObjectKeys(keys, true)
+	return a
+}
+
+func (a *Array) checkEveryObjectKeys(keys []string, exact bool) {
+	if a.chain.failed() {
+		return
+	}
+
+	expected := map[string]bool{}
+	for _, k := range keys {
+		expected[k] = true
+	}
+
+	errors := ""
+	for n, e := range a.value {
+		obj, ok := e.(map[string]interface{})
+		if !ok {
+			errors += fmt.Sprintf("\n\nelement [%d] is not an object:\n%s",
+				n, dumpValue(e))
+			continue
+		}
+
+		missing := []string{}
+		for _, k := range keys {
+			if _, ok := obj[k]; !ok {
+				missing = append(missing, k)
+			}
+		}
+
+		extra := []string{}
+		if exact {
+			for k := range obj {
+				if !expected[k] {
+					extra = append(extra, k)
+				}
+			}
+			sort.Strings(extra)
+		}
+
+		if len(missing) != 0 {
+			errors += fmt.Sprintf("\n\nelement [%d] is missing keys:\n%s",
+				n, dumpValue(missing))
+		}
+		if len(extra) != 0 {
+			errors += fmt.Sprintf("\n\nelement [%d] has extra keys:\n%s",
+				n, dumpValue(extra))
+		}
+	}
+
+	if errors != "" {
+		what := "keys"
+		if exact {
+			what = "only keys"
+		}
+		a.chain.failWith(failure{
+			assertType: "array",
+			assertion:  "expected every element to be object with " + what,
+			expected:   dumpValue(keys),
+			actual:     dumpValue(a.value),
+			details:    errors,
+		})
+	}
+}
+
 func (a *Array) containsElement(expected interface{}) bool {
 	for _, e := range a.value {
-		if reflect.DeepEqual(expected, e) {
+		if a.chain.equal(expected, e) {
 			return true
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestArrayFailed(t *testing.T) {
@@ -16,12 +17,15 @@ func TestArrayFailed(t *testing.T) {
 	value.chain.assertFailed(t)
 
 	value.Path("$").chain.assertFailed(t)
+	value.JSONPath("$").chain.assertFailed(t)
 	value.Schema("")
 
 	assert.False(t, value.Length() == nil)
 	assert.False(t, value.Element(0) == nil)
 	assert.False(t, value.Iter() == nil)
 	assert.True(t, len(value.Iter()) == 0)
+	assert.False(t, value.AsObjects() == nil)
+	assert.True(t, len(value.AsObjects()) == 0)
 
 	value.Length().chain.assertFailed(t)
 	value.Element(0).chain.assertFailed(t)
@@ -36,6 +40,28 @@ func TestArrayFailed(t *testing.T) {
 	value.Contains("foo")
 	value.NotContains("foo")
 	value.ContainsOnly("foo")
+	value.ContainsAll("foo")
+	value.ContainsAny("foo")
+	value.ContainsExactlyOnce("foo")
+	value.Distinct()
+	value.NotDistinct()
+	value.IsOrdered()
+	value.IsOrderedReverse()
+	value.EveryObjectHasKeys("foo")
+	value.EveryObjectHasOnlyKeys("foo")
+	value.Every(func(int, *Value) {
+		panic("unexpected call")
+	})
+	value.Some(func(int, *Value) bool {
+		panic("unexpected call")
+	})
+	value.Map(func(int, *Value) interface{} {
+		panic("unexpected call")
+	}).chain.assertFailed(t)
+	assert.Equal(t, "initial", value.Reduce("initial",
+		func(interface{}, *Value) interface{} {
+			panic("unexpected call")
+		}))
 }
 
 func TestArrayGetters(t *testing.T) {
@@ -72,6 +98,16 @@ func TestArrayGetters(t *testing.T) {
 	value.chain.assertFailed(t)
 	value.chain.reset()
 
+	assert.Equal(t, 123.0, value.Element(-1).Raw())
+	assert.Equal(t, "foo", value.Element(-2).Raw())
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	assert.Equal(t, nil, value.Element(-3).Raw())
+	value.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "index -3")
+	value.chain.reset()
+
 	it := value.Iter()
 	assert.Equal(t, 2, len(it))
 	assert.Equal(t, "foo", it[0].Raw())
@@ -452,3 +488,527 @@ func TestArrayConvertContains(t *testing.T) {
 	value.chain.assertFailed(t)
 	value.chain.reset()
 }
+
+func TestArrayEveryObjectHasKeys(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{
+		map[string]interface{}{"id": 1, "name": "foo"},
+		map[string]interface{}{"id": 2, "name": "bar", "extra": true},
+	})
+
+	value.EveryObjectHasKeys("id", "name")
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EveryObjectHasKeys("name", "id")
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EveryObjectHasKeys("id")
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EveryObjectHasKeys()
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EveryObjectHasKeys("id", "extra")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message,
+		"\nelement [0] is missing keys:\n [\n   \"extra\"\n ]")
+
+	value.EveryObjectHasOnlyKeys("id", "name", "extra")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.EveryObjectHasOnlyKeys("id", "name")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message,
+		"\nelement [1] has extra keys:\n [\n   \"extra\"\n ]")
+	assert.NotContains(t, reporter.message, "element [0]")
+
+	value2 := NewArray(reporter, []interface{}{
+		map[string]interface{}{"id": 1, "name": "foo"},
+		map[string]interface{}{"name": "bar", "id": 2},
+	})
+
+	value2.EveryObjectHasOnlyKeys("id", "name")
+	value2.chain.assertOK(t)
+	value2.chain.reset()
+
+	value2.EveryObjectHasOnlyKeys("id")
+	value2.chain.assertFailed(t)
+	value2.chain.reset()
+
+	value3 := NewArray(reporter, []interface{}{
+		map[string]interface{}{"id": 1},
+		"id",
+	})
+
+	value3.EveryObjectHasKeys("id")
+	value3.chain.assertFailed(t)
+	value3.chain.reset()
+
+	value3.EveryObjectHasOnlyKeys("id")
+	value3.chain.assertFailed(t)
+	value3.chain.reset()
+
+	value4 := NewArray(reporter, []interface{}{})
+
+	value4.EveryObjectHasKeys("id")
+	value4.chain.assertOK(t)
+	value4.chain.reset()
+
+	value4.EveryObjectHasOnlyKeys("id")
+	value4.chain.assertOK(t)
+	value4.chain.reset()
+}
+
+func TestArrayAsObjects(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{
+		map[string]interface{}{"foo": 1},
+		map[string]interface{}{"bar": 2},
+	})
+
+	objects := value.AsObjects()
+	value.chain.assertOK(t)
+
+	require.Equal(t, 2, len(objects))
+
+	objects[0].ContainsKey("foo").chain.assertOK(t)
+	objects[1].ContainsKey("bar").chain.assertOK(t)
+
+	objects[1].ContainsKey("foo").chain.assertFailed(t)
+	value.chain.assertOK(t)
+
+	empty := NewArray(reporter, []interface{}{})
+	assert.Equal(t, []*Object{}, empty.AsObjects())
+	empty.chain.assertOK(t)
+
+	mixed := NewArray(reporter, []interface{}{
+		map[string]interface{}{"foo": 1},
+		"str",
+		nil,
+	})
+
+	assert.Equal(t, []*Object{}, mixed.AsObjects())
+	mixed.chain.assertFailed(t)
+}
+
+func TestArrayContainsAllAny(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{
+		"foo", 123, map[string]interface{}{"a": 1},
+	})
+
+	value.ContainsAll()
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsAll(123.0, "foo")
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsAll(map[string]interface{}{"a": 1.0}, "foo", 123)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsAll("foo", "bar", 456)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message, `"bar"`)
+	assert.Contains(t, reporter.message, "456")
+
+	value.ContainsAny("bar", 123)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsAny(map[string]interface{}{"a": 1})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsAny("bar", 456, map[string]interface{}{"a": 2})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ContainsAny()
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ContainsAll(func() {})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ContainsAny(func() {})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestArrayContainsExactlyOnce(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{
+		"foo", 123, "bar", "bar", map[string]interface{}{"a": 1},
+	})
+
+	value.ContainsExactlyOnce()
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsExactlyOnce("foo", 123.0)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsExactlyOnce(map[string]interface{}{"a": 1.0}, "foo")
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsExactlyOnce("foo", "bar")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message, `"foo": 1`)
+	assert.Contains(t, reporter.message, `"bar": 2`)
+
+	value.ContainsExactlyOnce("foo", "baz")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message, `"baz": 0`)
+
+	value.ContainsExactlyOnce(func() {})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestArrayDistinct(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		value    []interface{}
+		distinct bool
+	}{
+		{[]interface{}{}, true},
+		{[]interface{}{"foo"}, true},
+		{[]interface{}{"foo", 123, "bar", nil}, true},
+		{[]interface{}{"1", 1, true}, true},
+		{[]interface{}{"foo", 123, "foo"}, false},
+		{[]interface{}{1, 1.0}, false},
+		{[]interface{}{nil, nil}, false},
+		{[]interface{}{
+			map[string]interface{}{"a": 1, "b": []interface{}{2}},
+			map[string]interface{}{"b": []interface{}{2.0}, "a": 1.0},
+		}, false},
+		{[]interface{}{
+			map[string]interface{}{"a": 1},
+			map[string]interface{}{"a": 2},
+		}, true},
+	}
+
+	for _, tc := range cases {
+		value := NewArray(reporter, tc.value)
+
+		value.Distinct()
+		if tc.distinct {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.reset()
+
+		value.NotDistinct()
+		if tc.distinct {
+			value.chain.assertFailed(t)
+		} else {
+			value.chain.assertOK(t)
+		}
+		value.chain.reset()
+	}
+
+	value := NewArray(reporter, []interface{}{"a", "b", "c", "b", "a"})
+
+	value.Distinct()
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message, "[1] and [3]")
+}
+
+func TestArrayIsOrdered(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		value     []interface{}
+		ordered   bool
+		reversed  bool
+		supported bool
+	}{
+		{[]interface{}{}, true, true, true},
+		{[]interface{}{1}, true, true, true},
+		{[]interface{}{1, 2, 2, 3}, true, false, true},
+		{[]interface{}{3, 2, 2, 1}, false, true, true},
+		{[]interface{}{1, 3, 2}, false, false, true},
+		{[]interface{}{2, 2, 2}, true, true, true},
+		{[]interface{}{"a", "ab", "b"}, true, false, true},
+		{[]interface{}{"b", "ab", "a"}, false, true, true},
+		{[]interface{}{"b", "a", "c"}, false, false, true},
+		{[]interface{}{1, "a"}, false, false, false},
+		{[]interface{}{"a", 1}, false, false, false},
+		{[]interface{}{true, false}, false, false, false},
+		{[]interface{}{nil, nil}, false, false, false},
+	}
+
+	for _, tc := range cases {
+		value := NewArray(reporter, tc.value)
+
+		value.IsOrdered()
+		if tc.ordered {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.reset()
+
+		value.IsOrderedReverse()
+		if tc.reversed {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.reset()
+	}
+
+	value := NewArray(reporter, []interface{}{1, 3, 2, 4})
+
+	value.IsOrdered()
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message, "[1] and [2]")
+
+	byID := func(a, b *Value) bool {
+		return a.Object().Value("id").Number().Raw() <
+			b.Object().Value("id").Number().Raw()
+	}
+
+	objects := NewArray(reporter, []interface{}{
+		map[string]interface{}{"id": 1, "name": "c"},
+		map[string]interface{}{"id": 2, "name": "b"},
+		map[string]interface{}{"id": 3, "name": "a"},
+	})
+
+	objects.IsOrdered(byID)
+	objects.chain.assertOK(t)
+	objects.chain.reset()
+
+	objects.IsOrderedReverse(byID)
+	objects.chain.assertFailed(t)
+	objects.chain.reset()
+
+	objects.IsOrdered()
+	objects.chain.assertFailed(t)
+	objects.chain.reset()
+
+	objects.IsOrdered(byID, byID)
+	objects.chain.assertFailed(t)
+	objects.chain.reset()
+}
+
+func TestArrayEvery(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{"foo", "bar", 123})
+
+	var indices []int
+	var elements []interface{}
+
+	value.Every(func(index int, v *Value) {
+		indices = append(indices, index)
+		elements = append(elements, v.Raw())
+	})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	assert.Equal(t, []int{0, 1, 2}, indices)
+	assert.Equal(t, []interface{}{"foo", "bar", 123.0}, elements)
+
+	value.Every(func(index int, v *Value) {
+		if index < 2 {
+			v.String().NotEmpty()
+		} else {
+			v.Number().Gt(100)
+		}
+	})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.Every(func(index int, v *Value) {
+		v.String().NotEmpty()
+	})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	empty := NewArray(reporter, []interface{}{})
+
+	empty.Every(func(index int, v *Value) {
+		v.chain.fail("fail")
+	})
+	empty.chain.assertOK(t)
+}
+
+func TestArrayMap(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{
+		map[string]interface{}{"id": 1, "name": "foo"},
+		map[string]interface{}{"id": 2, "name": "bar"},
+	})
+
+	var indexes []int
+
+	ids := value.Map(func(index int, v *Value) interface{} {
+		indexes = append(indexes, index)
+		return v.Object().Value("id").Raw()
+	})
+	ids.chain.assertOK(t)
+	value.chain.assertOK(t)
+
+	assert.Equal(t, []int{0, 1}, indexes)
+	assert.Equal(t, []interface{}{1.0, 2.0}, ids.Raw())
+
+	ids.ContainsOnly(2, 1)
+	ids.chain.assertOK(t)
+
+	type item struct {
+		Name string `json:"name"`
+	}
+
+	items := value.Map(func(index int, v *Value) interface{} {
+		return item{v.Object().Value("name").String().Raw()}
+	})
+	items.chain.assertOK(t)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "foo"},
+		map[string]interface{}{"name": "bar"},
+	}, items.Raw())
+
+	empty := NewArray(reporter, []interface{}{}).Map(
+		func(index int, v *Value) interface{} {
+			return nil
+		})
+	empty.chain.assertOK(t)
+	assert.Equal(t, []interface{}{}, empty.Raw())
+
+	bad := value.Map(func(index int, v *Value) interface{} {
+		return v.Object().Value("missing").Raw()
+	})
+	bad.chain.assertFailed(t)
+	value.chain.assertFailed(t)
+	assert.Nil(t, bad.Raw())
+	assert.Contains(t, reporter.message, "[0]")
+	value.chain.reset()
+
+	bad = value.Map(func(index int, v *Value) interface{} {
+		return func() {}
+	})
+	bad.chain.assertFailed(t)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestArrayReduce(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{
+		map[string]interface{}{"price": 10, "name": "foo"},
+		map[string]interface{}{"price": 20, "name": "bar"},
+	})
+
+	total := value.Reduce(0.0, func(acc interface{}, v *Value) interface{} {
+		return acc.(float64) + v.Object().Value("price").Number().Raw()
+	})
+	value.chain.assertOK(t)
+	assert.Equal(t, 30.0, total)
+
+	NewNumber(reporter, total.(float64)).Equal(30).chain.assertOK(t)
+
+	names := value.Reduce("", func(acc interface{}, v *Value) interface{} {
+		return acc.(string) + v.Object().Value("name").String().Raw()
+	})
+	value.chain.assertOK(t)
+	assert.Equal(t, "foobar", names)
+
+	empty := NewArray(reporter, []interface{}{}).Reduce(42,
+		func(acc interface{}, v *Value) interface{} {
+			panic("unexpected call")
+		})
+	assert.Equal(t, 42, empty)
+
+	bad := value.Reduce(0.0, func(acc interface{}, v *Value) interface{} {
+		return acc.(float64) + v.Object().Value("missing").Number().Raw()
+	})
+	value.chain.assertFailed(t)
+	assert.Equal(t, 0.0, bad)
+	assert.Contains(t, reporter.message, "[0]")
+	value.chain.reset()
+}
+
+func TestArraySome(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{"foo", "bar", 123})
+
+	var indices []int
+
+	value.Some(func(index int, v *Value) bool {
+		indices = append(indices, index)
+		return v.String().Raw() == "bar"
+	})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	assert.Equal(t, []int{0, 1}, indices)
+
+	value.Some(func(index int, v *Value) bool {
+		return v.Number().Raw() == 123
+	})
+	value.chain.assertOK(t)
+	assert.False(t, reporter.reported)
+	value.chain.reset()
+
+	value.Some(func(index int, v *Value) bool {
+		return v.String().Raw() == "baz"
+	})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.Some(func(index int, v *Value) bool {
+		v.Number()
+		return true
+	})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.Some(func(index int, v *Value) bool {
+		v.Boolean()
+		return true
+	})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	empty := NewArray(reporter, []interface{}{})
+
+	empty.Some(func(index int, v *Value) bool {
+		return true
+	})
+	empty.chain.assertFailed(t)
+}
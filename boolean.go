@@ -1,5 +1,7 @@
 package httpexpect
 
+import "fmt"
+
 // Boolean provides methods to inspect attached bool value
 // (Go representation of JSON boolean).
 type Boolean struct {
@@ -35,7 +37,7 @@ func (b *Boolean) Path(path string) *Value {
 
 // Schema is similar to Value.Schema.
 func (b *Boolean) Schema(schema interface{}) *Boolean {
-	checkSchema(&b.chain, b.value, schema)
+	checkSchema(&b.chain, "boolean", b.value, schema)
 	return b
 }
 
@@ -46,7 +48,12 @@ func (b *Boolean) Schema(schema interface{}) *Boolean {
 //  boolean.Equal(true)
 func (b *Boolean) Equal(value bool) *Boolean {
 	if !(b.value == value) {
-		b.chain.fail("expected boolean == %v, but got %v", value, b.value)
+		b.chain.failWith(failure{
+			assertType: "boolean",
+			assertion:  "expected boolean equal to",
+			expected:   fmt.Sprintf(" %v", value),
+			actual:     fmt.Sprintf(" %v", b.value),
+		})
 	}
 	return b
 }
@@ -58,25 +65,71 @@ func (b *Boolean) Equal(value bool) *Boolean {
 //  boolean.NotEqual(false)
 func (b *Boolean) NotEqual(value bool) *Boolean {
 	if !(b.value != value) {
-		b.chain.fail("expected boolean != %v, but got %v", value, b.value)
+		b.chain.failWith(failure{
+			assertType: "boolean",
+			assertion:  "expected boolean not equal to",
+			expected:   fmt.Sprintf(" %v", value),
+		})
 	}
 	return b
 }
 
 // True succeeds if boolean is true.
 //
+// It is equivalent to IsTrue and reports the same failure message.
+//
 // Example:
 //  boolean := NewBoolean(t, true)
 //  boolean.True()
 func (b *Boolean) True() *Boolean {
-	return b.Equal(true)
+	return b.IsTrue()
 }
 
 // False succeeds if boolean is false.
 //
+// It is equivalent to IsFalse and reports the same failure message.
+//
 // Example:
 //  boolean := NewBoolean(t, false)
 //  boolean.False()
 func (b *Boolean) False() *Boolean {
-	return b.Equal(false)
+	return b.IsFalse()
+}
+
+// IsTrue succeeds if boolean is true.
+//
+// Unlike Equal(true), it reports failure as a plain statement about
+// the value instead of an equality mismatch.
+//
+// Example:
+//  boolean := NewBoolean(t, true)
+//  boolean.IsTrue()
+func (b *Boolean) IsTrue() *Boolean {
+	if !b.value {
+		b.chain.failWith(failure{
+			assertType: "boolean",
+			assertion:  "expected boolean to be true",
+			details:    ", but it was false",
+		})
+	}
+	return b
+}
+
+// IsFalse succeeds if boolean is false.
+//
+// Unlike Equal(false), it reports failure as a plain statement about
+// the value instead of an equality mismatch.
+//
+// Example:
+//  boolean := NewBoolean(t, false)
+//  boolean.IsFalse()
+func (b *Boolean) IsFalse() *Boolean {
+	if b.value {
+		b.chain.failWith(failure{
+			assertType: "boolean",
+			assertion:  "expected boolean to be false",
+			details:    ", but it was true",
+		})
+	}
+	return b
 }
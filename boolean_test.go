@@ -22,6 +22,8 @@ func TestBooleanFailed(t *testing.T) {
 	value.NotEqual(false)
 	value.True()
 	value.False()
+	value.IsTrue()
+	value.IsFalse()
 }
 
 func TestBooleanGetters(t *testing.T) {
@@ -109,3 +111,53 @@ func TestBooleanFalse(t *testing.T) {
 	value.chain.assertOK(t)
 	value.chain.reset()
 }
+
+func TestBooleanIsTrueIsFalse(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value1 := NewBoolean(reporter, true)
+
+	value1.IsTrue()
+	value1.chain.assertOK(t)
+	value1.chain.reset()
+
+	value1.IsFalse()
+	value1.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "expected boolean to be false, but it was true")
+	value1.chain.reset()
+
+	value2 := NewBoolean(reporter, false)
+
+	value2.IsFalse()
+	value2.chain.assertOK(t)
+	value2.chain.reset()
+
+	value2.IsTrue()
+	value2.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "expected boolean to be true, but it was false")
+	value2.chain.reset()
+}
+
+func TestBooleanTrueFalseMessages(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value1 := NewBoolean(reporter, true)
+
+	value1.False()
+	value1.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "expected boolean to be false, but it was true")
+	value1.chain.reset()
+
+	value2 := NewBoolean(reporter, false)
+
+	value2.True()
+	value2.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "expected boolean to be true, but it was false")
+	value2.chain.reset()
+
+	value2.Equal(true)
+	value2.chain.assertFailed(t)
+	assert.Equal(t,
+		"\nexpected boolean equal to:\n true\n\nbut got:\n false", reporter.message)
+	value2.chain.reset()
+}
@@ -1,38 +1,259 @@
 package httpexpect
 
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// chain is not safe for concurrent use by default.
+//
+// If mu is non-nil, chain is thread-safe: failbit and reporter are only
+// accessed while mu is held. mu is shared by the chain and all its copies,
+// but every copy has its own failbit, like without mu. To avoid reading
+// failbit concurrently with its update, copies should be made using clone
+// or child instead of plain assignment.
 type chain struct {
-	reporter Reporter
-	failbit  bool
+	reporter        Reporter
+	failbit         bool
+	preserveNumbers bool
+	decoder         JSONDecoder
+	headerKeys      bool
+	comparator      func(a, b interface{}) bool
+	mu              *sync.Mutex
+	path            string
+	formatter       Formatter
+	timeLayout      string
+	handler         AssertionHandler
+	requestPath     string
+	requestDump     string
 }
 
 func makeChain(reporter Reporter) chain {
-	return chain{reporter, false}
+	return chain{reporter: reporter}
+}
+
+func makeConfigChain(config Config) chain {
+	chain := makeChain(config.Reporter)
+	chain.preserveNumbers = config.PreserveNumbers
+	chain.decoder = config.JSONDecoder
+	chain.formatter = config.Formatter
+	chain.timeLayout = config.TimeLayout
+	chain.handler = config.AssertionHandler
+	if config.ThreadSafe {
+		chain.mu = &sync.Mutex{}
+	}
+	return chain
+}
+
+// clone returns a copy of chain, which has its own fail state, initially
+// equal to the fail state of this chain.
+func (c *chain) clone() chain {
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	return *c
+}
+
+// child returns a clone of chain for nested value, with given segment
+// appended to the path. Segments are built by keyPath and indexPath.
+func (c *chain) child(segment string) chain {
+	ret := c.clone()
+	ret.path += segment
+	return ret
+}
+
+// keyPath returns path segment for object key, e.g. ".foo" or ["foo bar"].
+func keyPath(key string) string {
+	for i, r := range key {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' ||
+			i > 0 && r >= '0' && r <= '9') {
+			return fmt.Sprintf("[%q]", key)
+		}
+	}
+	if key == "" {
+		return `[""]`
+	}
+	return "." + key
+}
+
+// indexPath returns path segment for array index, e.g. "[3]".
+func indexPath(index int) string {
+	return fmt.Sprintf("[%d]", index)
 }
 
 func (c *chain) failed() bool {
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
 	return c.failbit
 }
 
+// setFailed marks chain as failed without reporting failure; used when
+// failure was already reported by a derived chain.
+func (c *chain) setFailed() {
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.failbit = true
+}
+
 func (c *chain) fail(message string, args ...interface{}) {
-	if c.failbit {
-		return
+	c.report(Failure{Message: fmt.Sprintf(message, args...)})
+}
+
+// failure describes failed assertion. It's passed to failWith, which
+// uses it both to build failure message and to fill Failure fields.
+type failure struct {
+	// type of checked value, e.g. "string"
+	assertType string
+	// assertion name, e.g. "expected string equal to"
+	assertion string
+	// expected and actual values formatted for message, e.g. by dumpValue;
+	// may be empty
+	expected string
+	actual   string
+	// header of actual value section; "but got" if empty
+	got string
+	// appended to message as is, e.g. "\n\ndiff:\n..."
+	details string
+	// keys that differ, when comparing objects
+	keys mapDiff
+}
+
+// message builds failure message, e.g.:
+//  expected string equal to:
+//   "foo"
+//
+//  but got:
+//   "bar"
+// If expected is empty, the first line is "<assertion>, but got:"; if
+// actual is empty, "but got" section is omitted.
+func (f *failure) message() string {
+	got := f.got
+	if got == "" {
+		got = "but got"
 	}
+	message := "\n" + f.assertion
+	switch {
+	case f.expected != "" && f.actual != "":
+		message += ":\n" + f.expected + "\n\n" + got + ":\n" + f.actual
+	case f.expected != "":
+		message += ":\n" + f.expected
+	case f.actual != "":
+		message += ", " + got + ":\n" + f.actual
+	}
+	return message + f.details
+}
+
+// failWith is like fail, but reports failure of an assertion, which fields
+// are also passed to FailureReporter, Formatter and AssertionHandler.
+func (c *chain) failWith(f failure) {
+	c.report(Failure{
+		AssertionName: f.assertion,
+		AssertType:    f.assertType,
+		Expected:      strings.TrimSpace(f.expected),
+		Actual:        strings.TrimSpace(f.actual),
+		OnlyExpected:  f.keys.onlyExpected,
+		OnlyActual:    f.keys.onlyActual,
+		Different:     f.keys.different,
+		Message:       f.message(),
+	})
+}
+
+// report marks chain as failed and reports failure, unless chain is
+// already failed. Path and request dump are added to the message here.
+//
+// AssertionHandler is invoked without holding mu, so that it may use the
+// chain, and before Reporter, which may terminate the test.
+func (c *chain) report(f Failure) {
+	if c.mu != nil {
+		c.mu.Lock()
+	}
+	failed := c.failbit
 	c.failbit = true
-	c.reporter.Errorf(message, args...)
+	if c.mu != nil {
+		c.mu.Unlock()
+	}
+	if failed {
+		return
+	}
+	f.Path = c.path
+	if c.path != "" {
+		prefix := "\nat " + c.path + ":"
+		if !strings.HasPrefix(f.Message, "\n") {
+			prefix += "\n"
+		}
+		f.Message = prefix + f.Message
+	}
+	if c.requestDump != "" {
+		f.Message += "\n\nrequest:\n" + c.requestDump
+	}
+	if c.handler != nil {
+		c.handler.Failure(&AssertionContext{
+			AssertionName: f.AssertionName,
+			Path:          c.path,
+			RequestPath:   c.requestPath,
+		}, f)
+	}
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	if r, ok := c.reporter.(FailureReporter); ok {
+		r.ReportFailure(f)
+	} else if c.formatter != nil {
+		c.reporter.Errorf("%s", c.formatter.Format(f))
+	} else {
+		c.reporter.Errorf("%s", f.Message)
+	}
+}
+
+// equal compares canonical values using comparator, if set, or
+// equalValues otherwise, which treats timestamps denoting the same
+// instant as equal.
+func (c *chain) equal(expected, actual interface{}) bool {
+	if c.comparator != nil {
+		return c.comparator(expected, actual)
+	}
+	return equalValues(expected, actual, c.timeLayout)
 }
 
 func (c *chain) reset() {
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
 	c.failbit = false
 }
 
 func (c *chain) assertFailed(r Reporter) {
-	if !c.failbit {
+	if !c.failed() {
 		r.Errorf("expected chain is failed, but it's ok")
 	}
 }
 
 func (c *chain) assertOK(r Reporter) {
-	if c.failbit {
+	if c.failed() {
 		r.Errorf("expected chain is ok, but it's failed")
 	}
 }
+
+// discardReporter is used for chains which failures should not be reported.
+type discardReporter struct{}
+
+func (discardReporter) Errorf(message string, args ...interface{}) {
+}
+
+// countingReporter is used for chains which failures should not be reported,
+// but should be detected, including failures of derived chains.
+type countingReporter struct {
+	count int
+}
+
+func (r *countingReporter) Errorf(message string, args ...interface{}) {
+	r.count++
+}
@@ -1,6 +1,11 @@
 package httpexpect
 
 import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -62,3 +67,388 @@ func TestChainReport(t *testing.T) {
 	chain.assertOK(r2)
 	assert.True(t, r2.reported)
 }
+
+func TestChainFailWith(t *testing.T) {
+	cases := []struct {
+		failure failure
+		message string
+	}{
+		{
+			failure{assertion: "expected foo"},
+			"\nexpected foo",
+		},
+		{
+			failure{assertion: "expected foo", expected: " 1"},
+			"\nexpected foo:\n 1",
+		},
+		{
+			failure{assertion: "expected foo", actual: " 2"},
+			"\nexpected foo, but got:\n 2",
+		},
+		{
+			failure{assertion: "expected foo", expected: " 1", actual: " 2"},
+			"\nexpected foo:\n 1\n\nbut got:\n 2",
+		},
+		{
+			failure{assertion: "expected foo", actual: " 2", got: "but got bar"},
+			"\nexpected foo, but got bar:\n 2",
+		},
+		{
+			failure{assertion: "expected foo", details: ", but it's not"},
+			"\nexpected foo, but it's not",
+		},
+	}
+
+	for _, tc := range cases {
+		reporter := newMockReporter(t)
+
+		chain := makeChain(reporter)
+		chain.failWith(tc.failure)
+
+		assert.True(t, chain.failed())
+		assert.Equal(t, tc.message, reporter.message)
+	}
+}
+
+func TestChainThreadSafe(t *testing.T) {
+	chain1 := makeConfigChain(Config{Reporter: newMockReporter(t)})
+	assert.Nil(t, chain1.mu)
+
+	reporter := &countingReporter{}
+
+	chain2 := makeConfigChain(Config{Reporter: reporter, ThreadSafe: true})
+	assert.NotNil(t, chain2.mu)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chain2.failed()
+			chain2.fail("fail")
+		}()
+	}
+	wg.Wait()
+
+	chain2.assertFailed(t)
+	assert.Equal(t, 1, reporter.count)
+
+	array := &Array{
+		makeConfigChain(Config{Reporter: reporter, ThreadSafe: true}),
+		[]interface{}{1.0, 2.0, 3.0, 4.0},
+	}
+
+	for i := range array.Raw() {
+		wg.Add(1)
+		go func(element *Value) {
+			defer wg.Done()
+			element.Number().Gt(2)
+			element.Number().Lt(3)
+		}(array.Element(i))
+	}
+	wg.Wait()
+
+	array.chain.assertOK(t)
+	assert.Equal(t, 5, reporter.count)
+}
+
+func TestChainThreadSafeCasts(t *testing.T) {
+	for _, threadSafe := range []bool{false, true} {
+		reporter := &countingReporter{}
+
+		resp := makeResponse(responseOpts{
+			chain: makeConfigChain(Config{Reporter: reporter, ThreadSafe: threadSafe}),
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{"foo": 123}`)),
+			},
+		})
+
+		resp.JSON().Object().ValueEqual("foo", 456)
+		resp.chain.assertOK(t)
+
+		resp.Status(http.StatusInternalServerError)
+		resp.chain.assertFailed(t)
+
+		assert.Equal(t, 2, reporter.count)
+	}
+}
+
+// Should be run with -race.
+func TestChainThreadSafeSameObject(t *testing.T) {
+	reporter := &countingReporter{}
+
+	object := &Object{
+		makeConfigChain(Config{Reporter: reporter, ThreadSafe: true}),
+		map[string]interface{}{
+			"a": 5.0,
+			"b": 7.0,
+			"c": []interface{}{1.0, "2"},
+		},
+	}
+
+	const n = 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			object.Value("a").Number().Equal(6)
+		}()
+		go func() {
+			defer wg.Done()
+			object.ValueEqual("b", 7)
+			object.ContainsKey("a")
+		}()
+		go func() {
+			defer wg.Done()
+			object.Value("c").Array().Every(func(_ int, value *Value) {
+				value.Number()
+			})
+		}()
+	}
+	wg.Wait()
+
+	object.chain.assertOK(t)
+	assert.Equal(t, 2*n, reporter.count)
+
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			object.ValueEqual("b", 8)
+		}()
+		go func() {
+			defer wg.Done()
+			object.Value("a").Number().Equal(5)
+			object.Clone().ValueEqual("a", 5)
+		}()
+	}
+	wg.Wait()
+
+	object.chain.assertFailed(t)
+	assert.Equal(t, 2*n+1, reporter.count)
+
+	clone := object.Clone()
+	clone.chain.assertOK(t)
+	object.chain.assertFailed(t)
+
+	clone.Value("a").Number().Equal(6).chain.assertFailed(t)
+	clone.chain.assertOK(t)
+}
+
+func TestChainPath(t *testing.T) {
+	assert.Equal(t, ".foo", keyPath("foo"))
+	assert.Equal(t, ".foo_1", keyPath("foo_1"))
+	assert.Equal(t, `["1foo"]`, keyPath("1foo"))
+	assert.Equal(t, `["foo bar"]`, keyPath("foo bar"))
+	assert.Equal(t, `["a\"b"]`, keyPath(`a"b`))
+	assert.Equal(t, `[""]`, keyPath(""))
+	assert.Equal(t, "[3]", indexPath(3))
+
+	reporter := newMockReporter(t)
+
+	chain := makeChain(reporter)
+	child := chain.child(keyPath("a"))
+	grandchild := child.child(indexPath(3))
+
+	assert.Equal(t, "", chain.path)
+	assert.Equal(t, ".a", child.path)
+	assert.Equal(t, ".a[3]", grandchild.path)
+
+	grandchild.fail("\nexpected %s", "number")
+	assert.Equal(t, "\nat .a[3]:\nexpected number", reporter.message)
+	assert.False(t, child.failed())
+
+	child.fail("100%% bad")
+	assert.Equal(t, "\nat .a:\n100% bad", reporter.message)
+
+	chain.fail("\nexpected %s", "object")
+	assert.Equal(t, "\nexpected object", reporter.message)
+}
+
+func TestChainPathNested(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	object := NewObject(reporter, map[string]interface{}{
+		"a": []interface{}{1, 2, 3, "four"},
+		"b c": map[string]interface{}{
+			"d": "foo",
+		},
+	})
+
+	object.Value("a").Array().Element(3).Number()
+	assert.Contains(t, reporter.message, "\nat .a[3]:\nexpected numeric value")
+
+	object.Value("a").Array().Last().Number()
+	assert.Contains(t, reporter.message, "\nat .a[3]:\n")
+
+	object.Value("a").Array().First().String()
+	assert.Contains(t, reporter.message, "\nat .a[0]:\n")
+
+	object.Value("a").Array().Iter()[1].Boolean()
+	assert.Contains(t, reporter.message, "\nat .a[1]:\n")
+
+	object.Value("a").Array().Every(func(index int, value *Value) {
+		value.Number()
+	})
+	assert.Contains(t, reporter.message, "\nat .a[3]:\n")
+
+	object.Value("b c").Object().Value("d").String().Equal("bar")
+	assert.Contains(t, reporter.message, "\nat [\"b c\"].d:\n")
+
+	object.Value("b c").Object().Value("d").String().Match(`f(o+)`).Index(1).
+		Equal("o")
+	assert.Contains(t, reporter.message, "\nat [\"b c\"].d[1]:\n")
+
+	object.Value("missing")
+	assert.False(t, strings.HasPrefix(reporter.message, "\nat "))
+}
+
+type mockFormatter struct{}
+
+func (mockFormatter) Format(failure Failure) string {
+	return "[" + failure.Path + "] " + failure.AssertionName
+}
+
+func TestChainFormatter(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	chain1 := makeConfigChain(Config{Reporter: reporter})
+	assert.Nil(t, chain1.formatter)
+
+	chain2 := makeConfigChain(Config{Reporter: reporter, Formatter: mockFormatter{}})
+
+	object := &Object{chain2, map[string]interface{}{"foo": "bar"}}
+	object.Value("foo").Number()
+	assert.Equal(t, "[.foo] expected numeric value", reporter.message)
+
+	chain3 := makeConfigChain(Config{Reporter: reporter, Formatter: DefaultFormatter{}})
+
+	for _, c := range []chain{makeChain(reporter), chain3} {
+		object := &Object{c, map[string]interface{}{"foo": "bar"}}
+		object.Value("foo").String().Equal("baz")
+		assert.Equal(t,
+			"\nat .foo:\nexpected string equal to:\n \"baz\"\n\nbut got:\n \"bar\"",
+			reporter.message)
+
+		c.fail("something went wrong")
+		assert.Equal(t, "something went wrong", reporter.message)
+	}
+}
+
+type mockAssertionHandler struct {
+	contexts []AssertionContext
+	failures []Failure
+}
+
+func (h *mockAssertionHandler) Failure(ctx *AssertionContext, failure Failure) {
+	h.contexts = append(h.contexts, *ctx)
+	h.failures = append(h.failures, failure)
+}
+
+func TestChainAssertionHandler(t *testing.T) {
+	reporter := newMockReporter(t)
+	handler := &mockAssertionHandler{}
+
+	chain := makeConfigChain(Config{Reporter: reporter, AssertionHandler: handler})
+
+	object := &Object{chain, map[string]interface{}{"foo": "bar"}}
+
+	object.Value("foo").String().Equal("bar")
+	assert.Equal(t, 0, len(handler.failures))
+
+	object.Value("foo").String().Equal("baz")
+	assert.Equal(t,
+		"\nat .foo:\nexpected string equal to:\n \"baz\"\n\nbut got:\n \"bar\"",
+		reporter.message)
+
+	if !assert.Equal(t, 1, len(handler.failures)) {
+		return
+	}
+	assert.Equal(t, AssertionContext{
+		AssertionName: "expected string equal to",
+		Path:          ".foo",
+	}, handler.contexts[0])
+	assert.Equal(t, ".foo", handler.failures[0].Path)
+	assert.Equal(t, `"baz"`, handler.failures[0].Expected)
+	assert.Equal(t, `"bar"`, handler.failures[0].Actual)
+}
+
+type mockAssertionHandlerFunc func(ctx *AssertionContext, failure Failure)
+
+func (f mockAssertionHandlerFunc) Failure(ctx *AssertionContext, failure Failure) {
+	f(ctx, failure)
+}
+
+type mockFatalT struct{}
+
+func (mockFatalT) Errorf(format string, args ...interface{}) {
+}
+
+func (mockFatalT) FailNow() {
+	panic("FailNow")
+}
+
+func TestChainAssertionHandlerOrder(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	called := false
+
+	var chain chain
+	chain = makeConfigChain(Config{
+		Reporter:   reporter,
+		ThreadSafe: true,
+		AssertionHandler: mockAssertionHandlerFunc(
+			func(ctx *AssertionContext, failure Failure) {
+				called = true
+				assert.True(t, chain.failed())
+				assert.False(t, reporter.reported)
+			}),
+	})
+
+	chain.fail("fail")
+	assert.True(t, called)
+	assert.True(t, reporter.reported)
+
+	called = false
+
+	fatalChain := makeConfigChain(Config{
+		Reporter: NewRequireReporter(mockFatalT{}),
+		AssertionHandler: mockAssertionHandlerFunc(
+			func(ctx *AssertionContext, failure Failure) {
+				called = true
+			}),
+	})
+
+	assert.Panics(t, func() {
+		fatalChain.fail("fail")
+	})
+	assert.True(t, called)
+}
+
+func TestChainAssertionHandlerRequest(t *testing.T) {
+	reporter := newMockReporter(t)
+	handler := &mockAssertionHandler{}
+
+	config := Config{
+		BaseURL:          "http://example.com",
+		RequestFactory:   DefaultRequestFactory{},
+		Client:           &mockClient{},
+		Reporter:         reporter,
+		AssertionHandler: handler,
+	}
+
+	req := NewRequest(config, "GET", "/users/{id}", 1)
+	req.Expect().Status(http.StatusTeapot)
+
+	if !assert.Equal(t, 1, len(handler.contexts)) {
+		return
+	}
+	assert.Equal(t, "/users/1", handler.contexts[0].RequestPath)
+	assert.Equal(t, "response", handler.failures[0].AssertType)
+}
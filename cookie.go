@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -47,9 +48,9 @@ func (c *Cookie) Raw() *http.Cookie {
 //  cookie.Name().Equal("session")
 func (c *Cookie) Name() *String {
 	if c.chain.failed() {
-		return &String{c.chain, ""}
+		return &String{c.chain.clone(), ""}
 	}
-	return &String{c.chain, c.value.Name}
+	return &String{c.chain.clone(), c.value.Name}
 }
 
 // Value returns a new String object that may be used to inspect
@@ -60,9 +61,9 @@ func (c *Cookie) Name() *String {
 //  cookie.Value().Equal("gH6z7Y")
 func (c *Cookie) Value() *String {
 	if c.chain.failed() {
-		return &String{c.chain, ""}
+		return &String{c.chain.clone(), ""}
 	}
-	return &String{c.chain, c.value.Value}
+	return &String{c.chain.clone(), c.value.Value}
 }
 
 // Domain returns a new String object that may be used to inspect
@@ -73,9 +74,9 @@ func (c *Cookie) Value() *String {
 //  cookie.Domain().Equal("example.com")
 func (c *Cookie) Domain() *String {
 	if c.chain.failed() {
-		return &String{c.chain, ""}
+		return &String{c.chain.clone(), ""}
 	}
-	return &String{c.chain, c.value.Domain}
+	return &String{c.chain.clone(), c.value.Domain}
 }
 
 // Path returns a new String object that may be used to inspect
@@ -86,22 +87,34 @@ func (c *Cookie) Domain() *String {
 //  cookie.Path().Equal("/foo")
 func (c *Cookie) Path() *String {
 	if c.chain.failed() {
-		return &String{c.chain, ""}
+		return &String{c.chain.clone(), ""}
 	}
-	return &String{c.chain, c.value.Path}
+	return &String{c.chain.clone(), c.value.Path}
 }
 
 // Expires returns a new DateTime object that may be used to inspect
 // cookie expiration date.
 //
+// If Expires attribute is not set (e.g. for session cookies), failure is
+// reported and empty (but non-nil) object is returned.
+//
 // Example:
 //  cookie := NewCookie(t, &http.Cookie{...})
 //  cookie.Expires().InRange(time.Now(), time.Now().Add(time.Hour * 24))
 func (c *Cookie) Expires() *DateTime {
 	if c.chain.failed() {
-		return &DateTime{c.chain, time.Unix(0, 0)}
+		return &DateTime{c.chain.clone(), time.Unix(0, 0)}
+	}
+	if c.value.Expires.IsZero() {
+		c.chain.failWith(failure{
+			assertType: "cookie",
+			assertion: fmt.Sprintf(
+				"expected cookie %q with Expires attribute", c.value.Name),
+			details: ", but it's not set",
+		})
+		return &DateTime{c.chain.clone(), time.Unix(0, 0)}
 	}
-	return &DateTime{c.chain, c.value.Expires}
+	return &DateTime{c.chain.clone(), c.value.Expires}
 }
 
 // MaxAge returns a new Duration object that may be used to inspect
@@ -119,15 +132,15 @@ func (c *Cookie) Expires() *DateTime {
 //  cookie.MaxAge().InRange(time.Minute, time.Minute*10)
 func (c *Cookie) MaxAge() *Duration {
 	if c.chain.failed() {
-		return &Duration{c.chain, nil}
+		return &Duration{c.chain.clone(), nil}
 	}
 	if c.value.MaxAge == 0 {
-		return &Duration{c.chain, nil}
+		return &Duration{c.chain.clone(), nil}
 	}
 	if c.value.MaxAge < 0 {
 		var zero time.Duration
-		return &Duration{c.chain, &zero}
+		return &Duration{c.chain.clone(), &zero}
 	}
 	d := time.Duration(c.value.MaxAge) * time.Second
-	return &Duration{c.chain, &d}
+	return &Duration{c.chain.clone(), &d}
 }
@@ -58,6 +58,38 @@ func TestCookieGetters(t *testing.T) {
 	value.chain.assertOK(t)
 }
 
+func TestCookieExpires(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("unset", func(t *testing.T) {
+		value := NewCookie(reporter, &http.Cookie{
+			Name: "session",
+		})
+
+		value.chain.assertOK(t)
+
+		value.Expires().chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		expires := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		value := NewCookie(reporter, &http.Cookie{
+			Name:    "session",
+			Expires: expires,
+		})
+
+		value.chain.assertOK(t)
+
+		value.Expires().Equal(expires).chain.assertOK(t)
+		value.Expires().InRange(expires.Add(-time.Hour), expires.Add(time.Hour)).
+			chain.assertOK(t)
+
+		value.chain.assertOK(t)
+	})
+}
+
 func TestCookieMaxAge(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -42,8 +43,12 @@ func (dt *DateTime) Raw() time.Time {
 //  dt.Equal(time.Unix(0, 1))
 func (dt *DateTime) Equal(value time.Time) *DateTime {
 	if !dt.value.Equal(value) {
-		dt.chain.fail("\nexpected datetime equal to:\n %s\n\nbut got:\n %s",
-			value, dt.value)
+		dt.chain.failWith(failure{
+			assertType: "datetime",
+			assertion:  "expected datetime equal to",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", dt.value),
+		})
 	}
 	return dt
 }
@@ -55,7 +60,11 @@ func (dt *DateTime) Equal(value time.Time) *DateTime {
 //  dt.NotEqual(time.Unix(0, 2))
 func (dt *DateTime) NotEqual(value time.Time) *DateTime {
 	if dt.value.Equal(value) {
-		dt.chain.fail("\nexpected datetime not equal to:\n %s", value)
+		dt.chain.failWith(failure{
+			assertType: "datetime",
+			assertion:  "expected datetime not equal to",
+			expected:   fmt.Sprintf(" %s", value),
+		})
 	}
 	return dt
 }
@@ -67,8 +76,12 @@ func (dt *DateTime) NotEqual(value time.Time) *DateTime {
 //  dt.Gt(time.Unix(0, 1))
 func (dt *DateTime) Gt(value time.Time) *DateTime {
 	if !dt.value.After(value) {
-		dt.chain.fail("\nexpected datetime > then:\n %s\n\nbut got:\n %s",
-			value, dt.value)
+		dt.chain.failWith(failure{
+			assertType: "datetime",
+			assertion:  "expected datetime > then",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", dt.value),
+		})
 	}
 	return dt
 }
@@ -80,8 +93,12 @@ func (dt *DateTime) Gt(value time.Time) *DateTime {
 //  dt.Ge(time.Unix(0, 1))
 func (dt *DateTime) Ge(value time.Time) *DateTime {
 	if !(dt.value.After(value) || dt.value.Equal(value)) {
-		dt.chain.fail("\nexpected datetime >= then:\n %s\n\nbut got:\n %s",
-			value, dt.value)
+		dt.chain.failWith(failure{
+			assertType: "datetime",
+			assertion:  "expected datetime >= then",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", dt.value),
+		})
 	}
 	return dt
 }
@@ -93,8 +110,12 @@ func (dt *DateTime) Ge(value time.Time) *DateTime {
 //  dt.Lt(time.Unix(0, 2))
 func (dt *DateTime) Lt(value time.Time) *DateTime {
 	if !dt.value.Before(value) {
-		dt.chain.fail("\nexpected datetime < then:\n %s\n\nbut got:\n %s",
-			value, dt.value)
+		dt.chain.failWith(failure{
+			assertType: "datetime",
+			assertion:  "expected datetime < then",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", dt.value),
+		})
 	}
 	return dt
 }
@@ -106,12 +127,36 @@ func (dt *DateTime) Lt(value time.Time) *DateTime {
 //  dt.Le(time.Unix(0, 2))
 func (dt *DateTime) Le(value time.Time) *DateTime {
 	if !(dt.value.Before(value) || dt.value.Equal(value)) {
-		dt.chain.fail("\nexpected datetime <= then:\n %s\n\nbut got:\n %s",
-			value, dt.value)
+		dt.chain.failWith(failure{
+			assertType: "datetime",
+			assertion:  "expected datetime <= then",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", dt.value),
+		})
 	}
 	return dt
 }
 
+// Before succeeds if DateTime is before given value.
+// It's an alias for Lt.
+//
+// Example:
+//  dt := NewDateTime(t, time.Unix(0, 1))
+//  dt.Before(time.Unix(0, 2))
+func (dt *DateTime) Before(value time.Time) *DateTime {
+	return dt.Lt(value)
+}
+
+// After succeeds if DateTime is after given value.
+// It's an alias for Gt.
+//
+// Example:
+//  dt := NewDateTime(t, time.Unix(0, 2))
+//  dt.After(time.Unix(0, 1))
+func (dt *DateTime) After(value time.Time) *DateTime {
+	return dt.Gt(value)
+}
+
 // InRange succeeds if DateTime is in given range [min; max].
 //
 // Example:
@@ -121,9 +166,47 @@ func (dt *DateTime) Le(value time.Time) *DateTime {
 func (dt *DateTime) InRange(min, max time.Time) *DateTime {
 	if !((dt.value.After(min) || dt.value.Equal(min)) &&
 		(dt.value.Before(max) || dt.value.Equal(max))) {
-		dt.chain.fail(
-			"\nexpected datetime in range:\n min: %s\n max: %s\n\nbut got: %s",
-			min, max, dt.value)
+		dt.chain.failWith(failure{
+			assertType: "datetime",
+			assertion:  "expected datetime in range",
+			expected:   fmt.Sprintf(" min: %s\n max: %s", min, max),
+			actual:     fmt.Sprintf(" %s", dt.value),
+		})
+	}
+	return dt
+}
+
+// Zero succeeds if DateTime is zero time instant, i.e. January 1, year 1,
+// 00:00:00 UTC, as reported by time.Time.IsZero.
+//
+// Note that Unix epoch is not zero time instant.
+//
+// Example:
+//  dt := NewDateTime(t, time.Time{})
+//  dt.Zero()
+func (dt *DateTime) Zero() *DateTime {
+	if !dt.value.IsZero() {
+		dt.chain.failWith(failure{
+			assertType: "datetime",
+			assertion:  "expected zero datetime",
+			actual:     fmt.Sprintf(" %s", dt.value),
+		})
+	}
+	return dt
+}
+
+// NotZero succeeds if DateTime is not zero time instant.
+//
+// Example:
+//  dt := NewDateTime(t, time.Unix(0, 1))
+//  dt.NotZero()
+func (dt *DateTime) NotZero() *DateTime {
+	if dt.value.IsZero() {
+		dt.chain.failWith(failure{
+			assertType: "datetime",
+			assertion:  "expected non-zero datetime",
+			actual:     fmt.Sprintf(" %s", dt.value),
+		})
 	}
 	return dt
 }
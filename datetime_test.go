@@ -24,7 +24,11 @@ func TestDateTimeFailed(t *testing.T) {
 	value.Ge(ts)
 	value.Lt(ts)
 	value.Le(ts)
+	value.Before(ts)
+	value.After(ts)
 	value.InRange(ts, ts)
+	value.Zero()
+	value.NotZero()
 }
 
 func TestDateTimeEqual(t *testing.T) {
@@ -103,6 +107,28 @@ func TestDateTimeLesser(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestDateTimeBeforeAfter(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewDateTime(reporter, time.Unix(0, 1234))
+
+	value.Before(time.Unix(0, 1234+1))
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.Before(time.Unix(0, 1234))
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.After(time.Unix(0, 1234-1))
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.After(time.Unix(0, 1234))
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
 func TestDateTimeInRange(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -132,3 +158,27 @@ func TestDateTimeInRange(t *testing.T) {
 	value.chain.assertFailed(t)
 	value.chain.reset()
 }
+
+func TestDateTimeZero(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value1 := NewDateTime(reporter, time.Time{})
+
+	value1.Zero()
+	value1.chain.assertOK(t)
+	value1.chain.reset()
+
+	value1.NotZero()
+	value1.chain.assertFailed(t)
+	value1.chain.reset()
+
+	value2 := NewDateTime(reporter, time.Unix(0, 0))
+
+	value2.Zero()
+	value2.chain.assertFailed(t)
+	value2.chain.reset()
+
+	value2.NotZero()
+	value2.chain.assertOK(t)
+	value2.chain.reset()
+}
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -42,7 +43,11 @@ func (d *Duration) Raw() time.Duration {
 //  d.IsSet()
 func (d *Duration) IsSet() *Duration {
 	if d.value == nil {
-		d.chain.fail("expected duration is set, but it is not")
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration is set",
+			details:    ", but it is not",
+		})
 	}
 	return d
 }
@@ -50,7 +55,11 @@ func (d *Duration) IsSet() *Duration {
 // NotSet succeeds if Duration is not set.
 func (d *Duration) NotSet() *Duration {
 	if d.value != nil {
-		d.chain.fail("expected duration is not set, but it is")
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration is not set",
+			details:    ", but it is",
+		})
 	}
 	return d
 }
@@ -62,12 +71,20 @@ func (d *Duration) NotSet() *Duration {
 //  d.Equal(time.Second)
 func (d *Duration) Equal(value time.Duration) *Duration {
 	if d.value == nil {
-		d.chain.fail("expected duration is set, but it is not")
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration is set",
+			details:    ", but it is not",
+		})
 		return d
 	}
 	if !(*d.value == value) {
-		d.chain.fail("\nexpected duration equal to:\n %s\n\nbut got:\n %s",
-			value, *d.value)
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration equal to",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", *d.value),
+		})
 	}
 	return d
 }
@@ -79,11 +96,19 @@ func (d *Duration) Equal(value time.Duration) *Duration {
 //  d.NotEqual(time.Minute)
 func (d *Duration) NotEqual(value time.Duration) *Duration {
 	if d.value == nil {
-		d.chain.fail("expected duration is set, but it is not")
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration is set",
+			details:    ", but it is not",
+		})
 		return d
 	}
 	if !(*d.value != value) {
-		d.chain.fail("\nexpected duration not equal to:\n %s", value)
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration not equal to",
+			expected:   fmt.Sprintf(" %s", value),
+		})
 	}
 	return d
 }
@@ -95,12 +120,20 @@ func (d *Duration) NotEqual(value time.Duration) *Duration {
 //  d.Gt(time.Second)
 func (d *Duration) Gt(value time.Duration) *Duration {
 	if d.value == nil {
-		d.chain.fail("expected duration is set, but it is not")
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration is set",
+			details:    ", but it is not",
+		})
 		return d
 	}
 	if !(*d.value > value) {
-		d.chain.fail("\nexpected duration > then:\n %s\n\nbut got:\n %s",
-			value, *d.value)
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration > then",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", *d.value),
+		})
 	}
 	return d
 }
@@ -112,12 +145,20 @@ func (d *Duration) Gt(value time.Duration) *Duration {
 //  d.Ge(time.Second)
 func (d *Duration) Ge(value time.Duration) *Duration {
 	if d.value == nil {
-		d.chain.fail("expected duration is set, but it is not")
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration is set",
+			details:    ", but it is not",
+		})
 		return d
 	}
 	if !(*d.value >= value) {
-		d.chain.fail("\nexpected duration >= then:\n %s\n\nbut got:\n %s",
-			value, *d.value)
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration >= then",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", *d.value),
+		})
 	}
 	return d
 }
@@ -129,12 +170,20 @@ func (d *Duration) Ge(value time.Duration) *Duration {
 //  d.Lt(time.Minute)
 func (d *Duration) Lt(value time.Duration) *Duration {
 	if d.value == nil {
-		d.chain.fail("expected duration is set, but it is not")
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration is set",
+			details:    ", but it is not",
+		})
 		return d
 	}
 	if !(*d.value < value) {
-		d.chain.fail("\nexpected duration < then:\n %s\n\nbut got:\n %s",
-			value, *d.value)
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration < then",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", *d.value),
+		})
 	}
 	return d
 }
@@ -146,12 +195,20 @@ func (d *Duration) Lt(value time.Duration) *Duration {
 //  d.Le(time.Minute)
 func (d *Duration) Le(value time.Duration) *Duration {
 	if d.value == nil {
-		d.chain.fail("expected duration is set, but it is not")
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration is set",
+			details:    ", but it is not",
+		})
 		return d
 	}
 	if !(*d.value <= value) {
-		d.chain.fail("\nexpected duration <= then:\n %s\n\nbut got:\n %s",
-			value, *d.value)
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration <= then",
+			expected:   fmt.Sprintf(" %s", value),
+			actual:     fmt.Sprintf(" %s", *d.value),
+		})
 	}
 	return d
 }
@@ -164,13 +221,20 @@ func (d *Duration) Le(value time.Duration) *Duration {
 //  d.InRange(time.Minute, time.Minute)
 func (d *Duration) InRange(min, max time.Duration) *Duration {
 	if d.value == nil {
-		d.chain.fail("expected duration is set, but it is not")
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration is set",
+			details:    ", but it is not",
+		})
 		return d
 	}
 	if !(*d.value >= min && *d.value <= max) {
-		d.chain.fail(
-			"\nexpected duration in range:\n min: %s\n max: %s\n\nbut got: %s",
-			min, max, *d.value)
+		d.chain.failWith(failure{
+			assertType: "duration",
+			assertion:  "expected duration in range",
+			expected:   fmt.Sprintf(" min: %s\n max: %s", min, max),
+			actual:     fmt.Sprintf(" %s", *d.value),
+		})
 	}
 	return d
 }
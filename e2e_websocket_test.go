@@ -8,6 +8,7 @@ import (
 
 	fastwebsocket "github.com/fasthttp/websocket"
 	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
 	"github.com/valyala/fasthttp"
 )
 
@@ -284,6 +285,50 @@ func TestE2EWebsocketTimeouts(t *testing.T) {
 	})
 }
 
+func TestE2EWebsocketExpectMessage(t *testing.T) {
+	blockCh := make(chan struct{}, 1)
+
+	handler := createWebsocketHandler(wsHandlerOpts{
+		preWrite: func() {
+			<-blockCh
+		},
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+	})
+
+	ws := e.GET("/test").WithWebsocketUpgrade().
+		Expect().
+		Status(http.StatusSwitchingProtocols).
+		Websocket()
+	defer ws.Disconnect()
+
+	blockCh <- struct{}{}
+
+	ws.WriteText("test").ExpectMessage(time.Second).TextMessage().Body().Equal("test")
+	ws.chain.assertOK(t)
+
+	defer func() {
+		blockCh <- struct{}{}
+	}()
+
+	start := time.Now()
+
+	msg := ws.WriteText("test").ExpectMessage(time.Millisecond * 10)
+	msg.chain.assertFailed(t)
+	ws.chain.assertFailed(t)
+
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Contains(t, reporter.message, "timed out waiting for WebSocket message")
+}
+
 func TestE2EWebsocketClosed(t *testing.T) {
 	t.Run("close-write", func(t *testing.T) {
 		handler := createWebsocketHandler(wsHandlerOpts{})
@@ -66,6 +66,7 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/cookiejar"
@@ -129,6 +130,68 @@ type Config struct {
 	// you're happy with their format, but want to send logs somewhere
 	// else instead of testing.TB.
 	Printers []Printer
+
+	// JSONDecoder is used to decode JSON bodies of responses and
+	// WebSocket messages, and to convert Go values passed to assertions
+	// into canonical form.
+	// May be nil.
+	//
+	// If nil, DefaultJSONDecoder is used, which calls encoding/json.
+	// You can provide custom implementation, e.g. jsoniter or a wrapper
+	// around json.Decoder with UseNumber enabled.
+	JSONDecoder JSONDecoder
+
+	// PreserveNumbers enables exact handling of large integers in JSON.
+	// May be false.
+	//
+	// By default, all JSON numbers are decoded as float64, so integers
+	// greater than 2^53 lose precision, e.g. 9007199254740993 is equal to
+	// 9007199254740992. If PreserveNumbers is true, integers that can't be
+	// represented exactly as float64 are kept as json.Number, both in
+	// responses and in values passed to assertions, and Number.Equal and
+	// Number.NotEqual compare them exactly.
+	PreserveNumbers bool
+
+	// ThreadSafe enables synchronization of failure reporting.
+	// May be false.
+	//
+	// By default, objects returned by Request and its descendants are
+	// expected to be used from a single goroutine. If ThreadSafe is true,
+	// fail state of a request and all objects derived from it is guarded by
+	// a mutex shared between them, so that assertions may be run on the same
+	// object from multiple goroutines, and Reporter is never called
+	// concurrently. This adds locking to every assertion, so it is disabled
+	// by default.
+	ThreadSafe bool
+
+	// Formatter is used to format failures before passing them to Reporter.
+	// May be nil.
+	//
+	// If nil, failure messages are passed to Reporter as is, which gives
+	// the same output as DefaultFormatter. You can provide custom
+	// implementation, e.g. to produce more compact or machine-readable
+	// messages. Not used if Reporter implements FailureReporter.
+	Formatter Formatter
+
+	// TimeLayout defines layout used to parse timestamps when comparing
+	// values. May be empty.
+	//
+	// When expected value is time.Time (e.g. passed to Value.Equal,
+	// Object.ValueEqual, or Array.Contains, or nested into a map or slice
+	// passed to Object.ContainsMap and similar), and actual value is a string,
+	// the string is parsed as a timestamp, and they are considered equal if
+	// they denote the same instant, even if they use different time zones.
+	// Other strings are always compared as is.
+	//
+	// If empty, only RFC 3339 is used. Otherwise, TimeLayout is tried first,
+	// and RFC 3339 is used as a fallback.
+	TimeLayout string
+
+	// AssertionHandler is notified about failed assertions. May be nil.
+	//
+	// If set, it is invoked for every failure in addition to Reporter,
+	// e.g. to emit metrics or structured logs.
+	AssertionHandler AssertionHandler
 }
 
 // RequestFactory is used to create all http.Request objects.
@@ -213,6 +276,54 @@ type Reporter interface {
 	Errorf(message string, args ...interface{})
 }
 
+// FailureReporter is an optional interface which may be implemented by
+// Reporter to receive failures in structured form. If reporter implements
+// it, ReportFailure is called instead of Errorf, and Formatter is not used.
+// JSONReporter and CollectingReporter implement this interface.
+type FailureReporter interface {
+	Reporter
+
+	// ReportFailure reports failure.
+	// Allowed to return normally or terminate test using t.FailNow().
+	ReportFailure(failure Failure)
+}
+
+// JSONDecoder is used to decode JSON content.
+// DefaultJSONDecoder implements this interface.
+type JSONDecoder interface {
+	// Unmarshal parses JSON-encoded data and stores the result into v.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Formatter is used to convert failures to messages passed to Reporter.
+// DefaultFormatter implements this interface.
+type Formatter interface {
+	// Format returns failure message.
+	Format(failure Failure) string
+}
+
+// AssertionHandler is used to observe failed assertions.
+type AssertionHandler interface {
+	// Failure is called when assertion fails, before failure is passed
+	// to Reporter, so it's called even if Reporter terminates the test.
+	// No locks are held during the call.
+	Failure(ctx *AssertionContext, failure Failure)
+}
+
+// AssertionContext describes the assertion passed to AssertionHandler.
+// Fields are empty if they are not available.
+type AssertionContext struct {
+	// AssertionName is the name of the assertion, e.g.
+	// "expected string equal to", same as in Failure.
+	AssertionName string
+
+	// Path is the path of the checked value, e.g. ".items[0]".
+	Path string
+
+	// RequestPath is the URL path of the request which response is checked.
+	RequestPath string
+}
+
 // LoggerReporter combines Logger and Reporter interfaces.
 type LoggerReporter interface {
 	Logger
@@ -229,6 +340,25 @@ func (DefaultRequestFactory) NewRequest(
 	return http.NewRequest(method, urlStr, body)
 }
 
+// DefaultJSONDecoder is the default JSONDecoder implementation which just
+// calls json.Unmarshal.
+type DefaultJSONDecoder struct{}
+
+// Unmarshal implements JSONDecoder.Unmarshal.
+func (DefaultJSONDecoder) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// DefaultFormatter is the default Formatter implementation which returns
+// Failure.Message unchanged, i.e. the same message that is reported when
+// no Formatter is set.
+type DefaultFormatter struct{}
+
+// Format implements Formatter.Format.
+func (DefaultFormatter) Format(failure Failure) string {
+	return failure.Message
+}
+
 // New returns a new Expect object.
 //
 // baseURL specifies URL to prepended to all request. My be empty. If non-empty,
@@ -310,6 +440,9 @@ func WithConfig(config Config) *Expect {
 	if config.WebsocketDialer == nil {
 		config.WebsocketDialer = &websocket.Dialer{}
 	}
+	if config.JSONDecoder == nil {
+		config.JSONDecoder = DefaultJSONDecoder{}
+	}
 	return &Expect{
 		config: config,
 	}
@@ -351,7 +484,9 @@ func NewJar() http.CookieJar {
 //     Status(http.StatusOK)
 func (e *Expect) Builder(builder func(*Request)) *Expect {
 	ret := *e
-	ret.builders = append(e.builders, builder)
+	ret.builders = make([]func(*Request), 0, len(e.builders)+1)
+	ret.builders = append(ret.builders, e.builders...)
+	ret.builders = append(ret.builders, builder)
 	return &ret
 }
 
@@ -375,7 +510,9 @@ func (e *Expect) Builder(builder func(*Request)) *Expect {
 // 	    Status(http.StatusNotFound)
 func (e *Expect) Matcher(matcher func(*Response)) *Expect {
 	ret := *e
-	ret.matchers = append(e.matchers, matcher)
+	ret.matchers = make([]func(*Response), 0, len(e.matchers)+1)
+	ret.matchers = append(ret.matchers, e.matchers...)
+	ret.matchers = append(ret.matchers, matcher)
 	return &ret
 }
 
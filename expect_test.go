@@ -81,6 +81,49 @@ func TestExpectBuilders(t *testing.T) {
 	assert.Equal(t, r1, reqs2[0])
 }
 
+func TestExpectBuildersIndependent(t *testing.T) {
+	client := &mockClient{}
+
+	reporter := NewAssertReporter(t)
+
+	config := Config{
+		Client:   client,
+		Reporter: reporter,
+	}
+
+	e := WithConfig(config)
+
+	var calls []string
+
+	e0 := e
+	for i := 0; i < 3; i++ {
+		e0 = e0.Builder(func(r *Request) {
+			calls = append(calls, "base")
+		})
+	}
+
+	e1 := e0.Builder(func(r *Request) {
+		calls = append(calls, "first")
+	})
+
+	e2 := e0.Builder(func(r *Request) {
+		calls = append(calls, "second")
+	})
+
+	e1.Request("METHOD", "/url")
+	assert.Equal(t, []string{"base", "base", "base", "first"}, calls)
+
+	calls = nil
+
+	e2.Request("METHOD", "/url")
+	assert.Equal(t, []string{"base", "base", "base", "second"}, calls)
+
+	calls = nil
+
+	e0.Request("METHOD", "/url")
+	assert.Equal(t, []string{"base", "base", "base"}, calls)
+}
+
 func TestExpectMatchers(t *testing.T) {
 	client := &mockClient{}
 
@@ -124,6 +167,49 @@ func TestExpectMatchers(t *testing.T) {
 	assert.Equal(t, resp2, resps2[0])
 }
 
+func TestExpectMatchersIndependent(t *testing.T) {
+	client := &mockClient{}
+
+	reporter := NewAssertReporter(t)
+
+	config := Config{
+		Client:   client,
+		Reporter: reporter,
+	}
+
+	e := WithConfig(config)
+
+	var calls []string
+
+	e0 := e
+	for i := 0; i < 3; i++ {
+		e0 = e0.Matcher(func(r *Response) {
+			calls = append(calls, "base")
+		})
+	}
+
+	e1 := e0.Matcher(func(r *Response) {
+		calls = append(calls, "first")
+	})
+
+	e2 := e0.Matcher(func(r *Response) {
+		calls = append(calls, "second")
+	})
+
+	e1.Request("METHOD", "/url").Expect()
+	assert.Equal(t, []string{"base", "base", "base", "first"}, calls)
+
+	calls = nil
+
+	e2.Request("METHOD", "/url").Expect()
+	assert.Equal(t, []string{"base", "base", "base", "second"}, calls)
+
+	calls = nil
+
+	e0.Request("METHOD", "/url").Expect()
+	assert.Equal(t, []string{"base", "base", "base"}, calls)
+}
+
 func TestExpectValues(t *testing.T) {
 	client := &mockClient{}
 
@@ -280,3 +366,17 @@ func TestExpectRequestFactory(t *testing.T) {
 	r3.chain.assertFailed(t)
 	assert.Nil(t, f3.lastreq)
 }
+
+func TestExpectJSONDecoder(t *testing.T) {
+	e1 := WithConfig(Config{
+		Reporter: NewAssertReporter(t),
+	})
+	assert.Equal(t, DefaultJSONDecoder{}, e1.config.JSONDecoder)
+
+	d2 := &mockJSONDecoder{}
+	e2 := WithConfig(Config{
+		Reporter:    NewAssertReporter(t),
+		JSONDecoder: d2,
+	})
+	assert.Equal(t, d2, e2.config.JSONDecoder)
+}
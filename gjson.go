@@ -0,0 +1,289 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func getGJSONPath(chain *chain, value interface{}, path string) *Value {
+	if chain.failed() {
+		return &Value{chain.clone(), nil}
+	}
+
+	segments, err := splitGJSONPath(path)
+	if err != nil {
+		chain.fail("\nexpected valid path:\n %q\n\nbut got error:\n %s",
+			path, err.Error())
+		return &Value{chain.clone(), nil}
+	}
+
+	result, seg, err := readGJSONPath(value, segments)
+	if err != nil {
+		chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected path resolvable in value",
+			expected:   fmt.Sprintf(" %q", path),
+			actual:     dumpValue(value),
+			details: fmt.Sprintf("\n\nfailed at segment:\n %q\n\nerror:\n %s",
+				seg, err.Error()),
+		})
+		return &Value{chain.clone(), nil}
+	}
+
+	return &Value{chain.clone(), result}
+}
+
+// splitGJSONPath splits path into dot-separated segments, handling
+// backslash escapes and dots inside "#(...)" queries.
+func splitGJSONPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+
+	var (
+		segments []string
+		current  strings.Builder
+		depth    int
+		quote    bool
+	)
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '\\' && i+1 < len(path):
+			if depth > 0 {
+				current.WriteByte(c)
+			}
+			i++
+			current.WriteByte(path[i])
+		case quote:
+			if c == '"' {
+				quote = false
+			}
+			current.WriteByte(c)
+		case c == '"' && depth > 0:
+			quote = true
+			current.WriteByte(c)
+		case c == '(':
+			depth++
+			current.WriteByte(c)
+		case c == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("unexpected ')' at %d", i)
+			}
+			depth--
+			current.WriteByte(c)
+		case c == '.' && depth == 0:
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	if depth != 0 || quote {
+		return nil, fmt.Errorf("unterminated query")
+	}
+
+	return append(segments, current.String()), nil
+}
+
+func readGJSONPath(
+	value interface{}, segments []string,
+) (result interface{}, failed string, err error) {
+	if len(segments) == 0 {
+		return value, "", nil
+	}
+
+	seg, rest := segments[0], segments[1:]
+
+	switch {
+	case seg == "#":
+		arr, ok := value.([]interface{})
+		if !ok {
+			return nil, seg, fmt.Errorf("expected array, but got %s", jsonType(value))
+		}
+		if len(rest) == 0 {
+			return float64(len(arr)), "", nil
+		}
+		return mapGJSONPath(arr, rest), "", nil
+
+	case strings.HasPrefix(seg, "#("):
+		arr, ok := value.([]interface{})
+		if !ok {
+			return nil, seg, fmt.Errorf("expected array, but got %s", jsonType(value))
+		}
+		all := strings.HasSuffix(seg, ")#")
+		cond := strings.TrimSuffix(strings.TrimPrefix(seg, "#("), "#")
+		if !strings.HasSuffix(cond, ")") {
+			return nil, seg, fmt.Errorf("invalid query")
+		}
+		pred, err := parseGJSONQuery(cond[:len(cond)-1])
+		if err != nil {
+			return nil, seg, err
+		}
+		var matches []interface{}
+		for _, elem := range arr {
+			if pred(elem) {
+				matches = append(matches, elem)
+			}
+		}
+		if all {
+			return mapGJSONPath(matches, rest), "", nil
+		}
+		if len(matches) == 0 {
+			return nil, seg, fmt.Errorf("no array element matches query")
+		}
+		return readGJSONPath(matches[0], rest)
+
+	default:
+		child, err := gjsonChild(value, seg)
+		if err != nil {
+			return nil, seg, err
+		}
+		return readGJSONPath(child, rest)
+	}
+}
+
+func mapGJSONPath(arr []interface{}, segments []string) []interface{} {
+	results := []interface{}{}
+	for _, elem := range arr {
+		if r, _, err := readGJSONPath(elem, segments); err == nil {
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+func gjsonChild(value interface{}, key string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if child, ok := v[key]; ok {
+			return child, nil
+		}
+		if strings.ContainsAny(key, "*?") {
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if matchWildcard(key, k) {
+					return v[k], nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("key not found")
+
+	case []interface{}:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("expected array index, but got %q", key)
+		}
+		if i < 0 || i >= len(v) {
+			return nil, fmt.Errorf("index out of range [0; %d)", len(v))
+		}
+		return v[i], nil
+
+	default:
+		return nil, fmt.Errorf("expected object or array, but got %s",
+			jsonType(value))
+	}
+}
+
+var gjsonOperators = []string{"==", "!=", "<=", ">=", "!%", "<", ">", "%"}
+
+// parseGJSONQuery parses query of form "path <op> <literal>", where path
+// may be empty to refer to the element itself, or just "path" to check
+// that path exists.
+func parseGJSONQuery(query string) (func(interface{}) bool, error) {
+	lhs, op, rhs := strings.TrimSpace(query), "", ""
+
+	for i := 0; i < len(query) && op == ""; i++ {
+		if query[i] == '"' {
+			break
+		}
+		for _, o := range gjsonOperators {
+			if strings.HasPrefix(query[i:], o) {
+				lhs = strings.TrimSpace(query[:i])
+				op = o
+				rhs = strings.TrimSpace(query[i+len(o):])
+				break
+			}
+		}
+	}
+
+	var segments []string
+	if lhs != "" {
+		var err error
+		if segments, err = splitGJSONPath(lhs); err != nil {
+			return nil, err
+		}
+	}
+
+	if op == "" {
+		if lhs == "" {
+			return nil, fmt.Errorf("empty query")
+		}
+		return func(value interface{}) bool {
+			_, _, err := readGJSONPath(value, segments)
+			return err == nil
+		}, nil
+	}
+
+	var literal interface{}
+	if err := json.Unmarshal([]byte(rhs), &literal); err != nil {
+		return nil, fmt.Errorf("invalid query value %s", rhs)
+	}
+
+	return func(value interface{}) bool {
+		actual, _, err := readGJSONPath(value, segments)
+		if err != nil {
+			return false
+		}
+		switch op {
+		case "%", "!%":
+			as, ok1 := actual.(string)
+			ls, ok2 := literal.(string)
+			matched := ok1 && ok2 && matchWildcard(ls, as)
+			return matched == (op == "%")
+		default:
+			return compareFilterValues(actual, op, literal)
+		}
+	}, nil
+}
+
+// matchWildcard matches str against pattern, where '*' matches any sequence
+// of characters and '?' matches a single character.
+func matchWildcard(pattern, str string) bool {
+	p, s := []rune(pattern), []rune(str)
+
+	star, mark := -1, 0
+	i, j := 0, 0
+
+	for j < len(s) {
+		switch {
+		case i < len(p) && (p[i] == '?' || p[i] == s[j]):
+			i++
+			j++
+		case i < len(p) && p[i] == '*':
+			star, mark = i, j
+			i++
+		case star >= 0:
+			i = star + 1
+			mark++
+			j = mark
+		default:
+			return false
+		}
+	}
+
+	for i < len(p) && p[i] == '*' {
+		i++
+	}
+
+	return i == len(p)
+}
@@ -1,10 +1,19 @@
 package httpexpect
 
 import (
+	"bytes"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/xeipuuv/gojsonschema"
 	"github.com/yalp/jsonpath"
@@ -25,19 +34,19 @@ func toString(str interface{}) (s string, ok bool) {
 
 func getPath(chain *chain, value interface{}, path string) *Value {
 	if chain.failed() {
-		return &Value{*chain, nil}
+		return &Value{chain.clone(), nil}
 	}
 
 	result, err := jsonpath.Read(value, path)
 	if err != nil {
 		chain.fail(err.Error())
-		return &Value{*chain, nil}
+		return &Value{chain.clone(), nil}
 	}
 
-	return &Value{*chain, result}
+	return &Value{chain.clone(), result}
 }
 
-func checkSchema(chain *chain, value, schema interface{}) {
+func checkSchema(chain *chain, assertType string, value, schema interface{}) {
 	if chain.failed() {
 		return
 	}
@@ -71,11 +80,13 @@ func checkSchema(chain *chain, value, schema interface{}) {
 			errors += fmt.Sprintf(" %s\n", err)
 		}
 
-		chain.fail(
-			"\njson schema validation failed, schema:\n%s\n\nvalue:%s\n\nerrors:\n%s",
-			dumpSchema(schema),
-			dumpValue(value),
-			errors)
+		chain.failWith(failure{
+			assertType: assertType,
+			assertion:  "expected value matching json schema",
+			expected:   dumpSchema(schema),
+			actual:     dumpValue(value),
+			details:    "\n\nerrors:\n" + strings.TrimSuffix(errors, "\n"),
+		})
 
 		return
 	}
@@ -107,7 +118,8 @@ func canonArray(chain *chain, in interface{}) ([]interface{}, bool) {
 	if ok {
 		out, ok = data.([]interface{})
 		if !ok {
-			chain.fail("expected array, got %v", out)
+			chain.fail("\nexpected array or slice, but got %T:\n%s",
+				in, dumpValue(data))
 		}
 	}
 	return out, ok
@@ -119,7 +131,8 @@ func canonMap(chain *chain, in interface{}) (map[string]interface{}, bool) {
 	if ok {
 		out, ok = data.(map[string]interface{})
 		if !ok {
-			chain.fail("expected map, got %v", out)
+			chain.fail("\nexpected map with string keys or struct, but got %T:\n%s",
+				in, dumpValue(data))
 		}
 	}
 	return out, ok
@@ -132,13 +145,350 @@ func canonValue(chain *chain, in interface{}) (interface{}, bool) {
 		return nil, false
 	}
 
+	return decodeJSON(chain, chain.decoder, b)
+}
+
+// unmarshalCanon decodes JSON into canonical form. If preserveNumbers is
+// true, integers that can't be represented exactly as float64 are kept as
+// json.Number; all other numbers are decoded as float64.
+func unmarshalCanon(data []byte, preserveNumbers bool) (interface{}, error) {
 	var out interface{}
-	if err := json.Unmarshal(b, &out); err != nil {
+
+	if !preserveNumbers {
+		if err := json.Unmarshal(data, &out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid data after top-level JSON value")
+	}
+
+	return canonNumbers(out), nil
+}
+
+func canonNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		return canonJSONNumber(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = canonNumbers(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = canonNumbers(v[k])
+		}
+	}
+	return value
+}
+
+func canonJSONNumber(number json.Number) interface{} {
+	r, ok := new(big.Rat).SetString(string(number))
+	if !ok {
+		f, _ := number.Float64()
+		return f
+	}
+	f, exact := r.Float64()
+	if exact || !r.IsInt() {
+		return f
+	}
+	return json.Number(r.Num().String())
+}
+
+// exactInteger converts integer value of any numeric type or json.Number
+// to big.Int. If value is not an integer, ok is false.
+func exactInteger(value interface{}) (i *big.Int, ok bool) {
+	if number, isNumber := value.(json.Number); isNumber {
+		r, ok := new(big.Rat).SetString(string(number))
+		if !ok || !r.IsInt() {
+			return nil, false
+		}
+		return r.Num(), true
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), true
+
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+			return nil, false
+		}
+		i, _ := big.NewFloat(f).Int(nil)
+		return i, true
+
+	default:
+		return nil, false
+	}
+}
+
+// numberFloat returns float64 approximation of canonical number, which is
+// either float64 or json.Number.
+func numberFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, _ := strconv.ParseFloat(string(v), 64)
+		return f, true
+	default:
+		return 0, false
+	}
+}
+
+func decodeJSON(
+	chain *chain, decoder JSONDecoder, data []byte,
+) (interface{}, bool) {
+	if decoder == nil {
+		decoder = DefaultJSONDecoder{}
+	}
+
+	if _, ok := decoder.(DefaultJSONDecoder); ok {
+		value, err := unmarshalCanon(data, chain.preserveNumbers)
+		if err != nil {
+			chain.fail(err.Error())
+			return nil, false
+		}
+		return value, true
+	}
+
+	var value interface{}
+	if err := decoder.Unmarshal(data, &value); err != nil {
 		chain.fail(err.Error())
 		return nil, false
 	}
 
-	return out, true
+	// custom decoder may produce values of other types, e.g. json.Number,
+	// so convert them to canonical form
+	b, err := json.Marshal(value)
+	if err != nil {
+		chain.fail(err.Error())
+		return nil, false
+	}
+
+	value, err = unmarshalCanon(b, chain.preserveNumbers)
+	if err != nil {
+		chain.fail(err.Error())
+		return nil, false
+	}
+
+	return value, true
+}
+
+func canonDecode(chain *chain, value interface{}, target interface{}) bool {
+	if target == nil {
+		chain.fail("\nunexpected nil target passed to Decode")
+		return false
+	}
+
+	if rv := reflect.ValueOf(target); rv.Kind() != reflect.Ptr || rv.IsNil() {
+		chain.fail("\nexpected non-nil pointer passed to Decode, but got:\n %T",
+			target)
+		return false
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		chain.fail(err.Error())
+		return false
+	}
+
+	if err := json.Unmarshal(b, target); err != nil {
+		chain.fail("\nexpected value decodable into %T, but got error:\n %s",
+			target, err.Error())
+		return false
+	}
+
+	return true
+}
+
+// newUUID generates a random UUID (version 4, RFC 4122).
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// jsonType returns JSON type name of canonical value: "null", "boolean",
+// "number", "string", "array", or "object".
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// equalValues is like reflect.DeepEqual for canonical values, but
+// time.Time values in expected (see markTimes) are compared with strings in
+// actual as time instants, after parsing strings with parseTimestamp.
+func equalValues(expected, actual interface{}, layout string) bool {
+	switch ev := expected.(type) {
+	case time.Time:
+		as, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		at, ok := parseTimestamp(as, layout)
+		return ok && at.Equal(ev)
+
+	case map[string]interface{}:
+		av, ok := actual.(map[string]interface{})
+		if !ok || len(av) != len(ev) {
+			return false
+		}
+		for k, e := range ev {
+			a, ok := av[k]
+			if !ok || !equalValues(e, a, layout) {
+				return false
+			}
+		}
+		return true
+
+	case []interface{}:
+		av, ok := actual.([]interface{})
+		if !ok || len(av) != len(ev) {
+			return false
+		}
+		for i := range ev {
+			if !equalValues(ev[i], av[i], layout) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(expected, actual)
+}
+
+// markTimes restores time.Time values in canon, which is canonical form of
+// in, where in has time.Time values (or pointers to them), so that
+// equalValues can compare them as time instants. Nested maps and slices
+// are updated in place; struct fields are left as is.
+func markTimes(in, canon interface{}) interface{} {
+	v := reflect.ValueOf(in)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return canon
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return canon
+	}
+
+	if v.Type() == reflect.TypeOf(time.Time{}) && v.CanInterface() {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		m, ok := canon.(map[string]interface{})
+		if ok && v.Type().Key().Kind() == reflect.String {
+			for _, k := range v.MapKeys() {
+				if e, ok := m[k.String()]; ok {
+					m[k.String()] = markTimes(v.MapIndex(k).Interface(), e)
+				}
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		a, ok := canon.([]interface{})
+		if ok && len(a) == v.Len() {
+			for i := range a {
+				a[i] = markTimes(v.Index(i).Interface(), a[i])
+			}
+		}
+	}
+
+	return canon
+}
+
+// parseTimestamp parses string using given layout, if it's non-empty, and
+// falls back to RFC 3339, which is used by encoding/json for time.Time.
+func parseTimestamp(s, layout string) (time.Time, bool) {
+	if layout != "" {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// equalDelta compares two canonical values recursively, treating numbers as
+// equal if they are within delta of each other.
+func equalDelta(expected, actual interface{}, delta float64) bool {
+	switch ev := expected.(type) {
+	case float64, json.Number:
+		ef, _ := numberFloat(ev)
+		af, ok := numberFloat(actual)
+		if !ok || math.IsNaN(ef) || math.IsNaN(af) || math.IsNaN(delta) {
+			return false
+		}
+		return math.Abs(ef-af) <= delta
+
+	case map[string]interface{}:
+		av, ok := actual.(map[string]interface{})
+		if !ok || len(ev) != len(av) {
+			return false
+		}
+		for k, e := range ev {
+			a, ok := av[k]
+			if !ok || !equalDelta(e, a, delta) {
+				return false
+			}
+		}
+		return true
+
+	case []interface{}:
+		av, ok := actual.([]interface{})
+		if !ok || len(ev) != len(av) {
+			return false
+		}
+		for n := range ev {
+			if !equalDelta(ev[n], av[n], delta) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		return reflect.DeepEqual(expected, actual)
+	}
 }
 
 func dumpValue(value interface{}) string {
@@ -1,6 +1,10 @@
 package httpexpect
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -67,6 +71,16 @@ func TestCanonArray(t *testing.T) {
 	chain.assertFailed(t)
 	chain.reset()
 
+	reporter := newMockReporter(t)
+	chain = makeChain(reporter)
+
+	_, ok = canonArray(&chain, map[string]int{"foo": 123})
+	assert.False(t, ok)
+	chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "map[string]int")
+	assert.Contains(t, reporter.message, `"foo": 123`)
+	chain.reset()
+
 	_, ok = canonArray(&chain, func() {})
 	assert.False(t, ok)
 	chain.assertFailed(t)
@@ -103,11 +117,43 @@ func TestCanonMap(t *testing.T) {
 	chain.assertOK(t)
 	chain.reset()
 
+	d3, ok := canonMap(&chain, map[string]string{"foo": "bar"})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, d3)
+	chain.assertOK(t)
+	chain.reset()
+
+	d4, ok := canonMap(&chain, map[string]int{"foo": 123})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"foo": 123.0}, d4)
+	chain.assertOK(t)
+	chain.reset()
+
+	d5, ok := canonMap(&chain, map[string]map[string][]int{
+		"foo": {"bar": {1, 2}},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{
+		"foo": map[string]interface{}{"bar": []interface{}{1.0, 2.0}},
+	}, d5)
+	chain.assertOK(t)
+	chain.reset()
+
 	_, ok = canonMap(&chain, "123")
 	assert.False(t, ok)
 	chain.assertFailed(t)
 	chain.reset()
 
+	reporter := newMockReporter(t)
+	chain = makeChain(reporter)
+
+	_, ok = canonMap(&chain, []int{1, 2})
+	assert.False(t, ok)
+	chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "[]int")
+	assert.NotContains(t, reporter.message, "<nil>")
+	chain.reset()
+
 	_, ok = canonMap(&chain, func() {})
 	assert.False(t, ok)
 	chain.assertFailed(t)
@@ -124,6 +170,100 @@ func TestCanonMap(t *testing.T) {
 	chain.reset()
 }
 
+func TestCanonPreserveNumbers(t *testing.T) {
+	chain := makeChain(newMockReporter(t))
+
+	d1, ok := canonValue(&chain, int64(9007199254740993))
+	assert.True(t, ok)
+	assert.Equal(t, 9007199254740992.0, d1)
+	chain.assertOK(t)
+	chain.reset()
+
+	chain.preserveNumbers = true
+
+	d2, ok := canonValue(&chain, map[string]interface{}{
+		"big":   int64(9007199254740993),
+		"int":   123,
+		"float": 1.5,
+		"array": []interface{}{uint64(18446744073709551615), 0.1},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{
+		"big":   json.Number("9007199254740993"),
+		"int":   123.0,
+		"float": 1.5,
+		"array": []interface{}{json.Number("18446744073709551615"), 0.1},
+	}, d2)
+	chain.assertOK(t)
+	chain.reset()
+
+	d3, err := unmarshalCanon([]byte(`[9007199254740993, 1e2, 1E400]`), true)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		json.Number("9007199254740993"),
+		100.0,
+		json.Number("1" + strings.Repeat("0", 400)),
+	}, d3)
+
+	_, err = unmarshalCanon([]byte(`{} {}`), true)
+	assert.Error(t, err)
+
+	_, err = unmarshalCanon([]byte(`{`), true)
+	assert.Error(t, err)
+}
+
+func TestCanonJSONDecoder(t *testing.T) {
+	decoder := &mockJSONDecoder{}
+
+	chain := makeConfigChain(Config{
+		Reporter:    newMockReporter(t),
+		JSONDecoder: decoder,
+	})
+
+	d1, ok := canonValue(&chain, map[string]interface{}{"foo": 123})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"foo": 123.0}, d1)
+	assert.Equal(t, 1, decoder.calls)
+	chain.assertOK(t)
+	chain.reset()
+
+	decoder.err = errors.New("decode error")
+
+	_, ok = canonValue(&chain, map[string]interface{}{"foo": 123})
+	assert.False(t, ok)
+	assert.Equal(t, 2, decoder.calls)
+	chain.assertFailed(t)
+	chain.reset()
+}
+
+func TestExactInteger(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected string
+		ok       bool
+	}{
+		{123, "123", true},
+		{int64(-9007199254740993), "-9007199254740993", true},
+		{uint64(18446744073709551615), "18446744073709551615", true},
+		{123.0, "123", true},
+		{1.5, "", false},
+		{math.NaN(), "", false},
+		{math.Inf(1), "", false},
+		{json.Number("9007199254740993"), "9007199254740993", true},
+		{json.Number("1.5"), "", false},
+		{"123", "", false},
+		{nil, "", false},
+	}
+
+	for _, tc := range cases {
+		i, ok := exactInteger(tc.value)
+		assert.Equal(t, tc.ok, ok, "%v", tc.value)
+		if ok {
+			assert.Equal(t, tc.expected, i.String())
+		}
+	}
+}
+
 func TestDiffErrors(t *testing.T) {
 	na := " (unavailable)"
 
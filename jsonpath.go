@@ -0,0 +1,306 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/yalp/jsonpath"
+)
+
+func getJSONPath(chain *chain, value interface{}, expr string) *Value {
+	if chain.failed() {
+		return &Value{chain.clone(), nil}
+	}
+
+	result, err := readJSONPath(value, expr)
+	if err != nil {
+		chain.fail("\nfailed to evaluate JSONPath expression:\n %s\n\nerror:\n %s",
+			expr, err.Error())
+		return &Value{chain.clone(), nil}
+	}
+
+	return &Value{chain.clone(), result}
+}
+
+// readJSONPath evaluates expression using yalp/jsonpath, adding support
+// for filter expressions like "[?(@.key == value)]".
+func readJSONPath(value interface{}, expr string) (interface{}, error) {
+	begin := strings.Index(expr, "[?(")
+	if begin < 0 {
+		return jsonpath.Read(value, expr)
+	}
+
+	end, err := findFilterEnd(expr, begin+3)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, filter, rest := expr[:begin], expr[begin+3:end], expr[end+2:]
+
+	parent, err := jsonpath.Read(value, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	pred, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// If prefix may match multiple nodes, parent is the list of matched
+	// nodes, and filter should be applied to children of each of them.
+	candidates := jsonPathChildren(parent)
+	if arr, ok := parent.([]interface{}); ok && isMultiJSONPath(prefix) {
+		candidates = nil
+		for _, node := range arr {
+			candidates = append(candidates, jsonPathChildren(node)...)
+		}
+	}
+
+	// Elements for which rest can't be evaluated are skipped, e.g. if some
+	// of them don't have a key; but if no element has matched, the error is
+	// returned, e.g. if rest has a typo.
+	var restErr error
+
+	matches := []interface{}{}
+	for _, elem := range candidates {
+		if !pred(elem) {
+			continue
+		}
+		if rest == "" {
+			matches = append(matches, elem)
+			continue
+		}
+		sub, err := readJSONPath(elem, "$"+rest)
+		if err != nil {
+			if restErr == nil {
+				restErr = err
+			}
+			continue
+		}
+		if arr, ok := sub.([]interface{}); ok && isMultiJSONPath(rest) {
+			matches = append(matches, arr...)
+		} else {
+			matches = append(matches, sub)
+		}
+	}
+
+	if len(matches) == 0 && restErr != nil {
+		return nil, restErr
+	}
+
+	return matches, nil
+}
+
+func findFilterEnd(expr string, pos int) (int, error) {
+	depth := 0
+	var quote byte
+
+	for i := pos; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth > 0 {
+				depth--
+			} else if i+1 < len(expr) && expr[i+1] == ']' {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated filter expression at %d", pos-3)
+}
+
+func jsonPathChildren(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		children := make([]interface{}, 0, len(v))
+		for _, k := range keys {
+			children = append(children, v[k])
+		}
+		return children
+	default:
+		return nil
+	}
+}
+
+func isMultiJSONPath(path string) bool {
+	return strings.Contains(path, "*") ||
+		strings.Contains(path, "..") ||
+		strings.Contains(path, ",") ||
+		strings.Contains(path, ":") ||
+		strings.Contains(path, "[?(")
+}
+
+var jsonPathOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// parseFilter parses filter expression of form "@.path" (key exists) or
+// "@.path <op> <literal>", where op is one of ==, !=, <, <=, >, >=, and
+// literal is a JSON number, string (single or double quoted), true, false,
+// or null.
+func parseFilter(filter string) (func(interface{}) bool, error) {
+	filter = strings.TrimSpace(filter)
+
+	lhs, op, rhs := filter, "", ""
+
+	var quote byte
+loop:
+	for i := 0; i < len(filter); i++ {
+		c := filter[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		default:
+			for _, o := range jsonPathOperators {
+				if strings.HasPrefix(filter[i:], o) {
+					lhs = strings.TrimSpace(filter[:i])
+					op = o
+					rhs = strings.TrimSpace(filter[i+len(o):])
+					break loop
+				}
+			}
+		}
+	}
+
+	if !strings.HasPrefix(lhs, "@") {
+		return nil, fmt.Errorf("unsupported filter expression %q", filter)
+	}
+	path := "$" + lhs[1:]
+
+	if op == "" {
+		if hasUnquotedOperator(lhs) {
+			return nil, fmt.Errorf("unsupported filter expression %q", filter)
+		}
+		return func(value interface{}) bool {
+			_, err := jsonpath.Read(value, path)
+			return err == nil
+		}, nil
+	}
+
+	literal, err := parseFilterLiteral(rhs)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported filter expression %q: %s",
+			filter, err.Error())
+	}
+
+	return func(value interface{}) bool {
+		actual, err := jsonpath.Read(value, path)
+		if err != nil {
+			return false
+		}
+		return compareFilterValues(actual, op, literal)
+	}, nil
+}
+
+// hasUnquotedOperator reports whether s contains white space or operator
+// characters outside of quotes, e.g. "@.x = 1" or "@.x =~ 1".
+func hasUnquotedOperator(s string) bool {
+	var quote rune
+	for _, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case strings.ContainsRune("=!<>~&| \t", c):
+			return true
+		}
+	}
+	return false
+}
+
+func parseFilterLiteral(s string) (interface{}, error) {
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return s[1 : len(s)-1], nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return nil, errors.New("invalid literal " + s)
+	}
+
+	switch value.(type) {
+	case []interface{}, map[string]interface{}:
+		return nil, errors.New("invalid literal " + s)
+	}
+
+	return value, nil
+}
+
+func compareFilterValues(actual interface{}, op string, literal interface{}) bool {
+	if af, ok := numberFloat(actual); ok {
+		lf, ok := numberFloat(literal)
+		if !ok {
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return af == lf
+		case "!=":
+			return af != lf
+		case "<":
+			return af < lf
+		case "<=":
+			return af <= lf
+		case ">":
+			return af > lf
+		case ">=":
+			return af >= lf
+		}
+	}
+
+	if as, ok := actual.(string); ok {
+		ls, ok := literal.(string)
+		if !ok {
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return as == ls
+		case "!=":
+			return as != ls
+		case "<":
+			return as < ls
+		case "<=":
+			return as <= ls
+		case ">":
+			return as > ls
+		case ">=":
+			return as >= ls
+		}
+	}
+
+	switch op {
+	case "==":
+		return reflect.DeepEqual(actual, literal)
+	case "!=":
+		return !reflect.DeepEqual(actual, literal)
+	default:
+		return false
+	}
+}
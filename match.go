@@ -1,7 +1,10 @@
 package httpexpect
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 // Match provides methods to inspect attached regexp match results.
@@ -64,7 +67,72 @@ func (m *Match) Raw() []string {
 //  m := NewMatch(t, submatches, names)
 //  m.Length().Equal(len(submatches))
 func (m *Match) Length() *Number {
-	return &Number{m.chain, float64(len(m.submatches))}
+	return &Number{m.chain.clone(), float64(len(m.submatches)), nil}
+}
+
+// LengthMin succeeds if number of submatches (like in Length, including
+// the whole match) is greater than or equal to n.
+//
+// Example:
+//  s := "http://example.com/users/john"
+//  r := regexp.MustCompile(`http://(.+)/users/(.+)`)
+//  m := NewMatch(t, r.FindStringSubmatch(s), nil)
+//  m.LengthMin(3)
+func (m *Match) LengthMin(n int) *Match {
+	if len(m.submatches) < n {
+		m.chain.failWith(failure{
+			assertType: "match",
+			assertion:  "expected number of submatches at least",
+			expected:   fmt.Sprintf(" %d", n),
+			actual:     fmt.Sprintf(" %d", len(m.submatches)),
+			details:    "\n\nsubmatches:\n" + dumpValue(m.submatches),
+		})
+	}
+	return m
+}
+
+// LengthMax succeeds if number of submatches (like in Length, including
+// the whole match) is less than or equal to n.
+//
+// Example:
+//  s := "http://example.com/users/john"
+//  r := regexp.MustCompile(`http://(.+)/users/(.+)`)
+//  m := NewMatch(t, r.FindStringSubmatch(s), nil)
+//  m.LengthMax(3)
+func (m *Match) LengthMax(n int) *Match {
+	if len(m.submatches) > n {
+		m.chain.failWith(failure{
+			assertType: "match",
+			assertion:  "expected number of submatches at most",
+			expected:   fmt.Sprintf(" %d", n),
+			actual:     fmt.Sprintf(" %d", len(m.submatches)),
+			details:    "\n\nsubmatches:\n" + dumpValue(m.submatches),
+		})
+	}
+	return m
+}
+
+// NamedLength returns a new Number object that may be used to inspect
+// number of named submatches that participated in the match.
+//
+// Named submatches which captured empty string (e.g. optional groups that
+// didn't match) are not counted.
+//
+// Example:
+//   s := "http://example.com/users/john"
+//
+//   r := regexp.MustCompile(`http://(?P<host>.+)/users/(?P<user>.+)(?P<tail>/.*)?`)
+//   m := NewMatch(t, r.FindStringSubmatch(s), r.SubexpNames())
+//
+//   m.NamedLength().Equal(2)
+func (m *Match) NamedLength() *Number {
+	n := 0
+	for _, index := range m.names {
+		if index < len(m.submatches) && m.submatches[index] != "" {
+			n++
+		}
+	}
+	return &Number{m.chain.clone(), float64(n), nil}
 }
 
 // Index returns a new String object that may be used to inspect submatch
@@ -89,9 +157,9 @@ func (m *Match) Index(index int) *String {
 			index,
 			0,
 			len(m.submatches))
-		return &String{m.chain, ""}
+		return &String{m.chain.clone(), ""}
 	}
-	return &String{m.chain, m.submatches[index]}
+	return &String{m.chain.child(indexPath(index)), m.submatches[index]}
 }
 
 // Name returns a new String object that may be used to inspect submatch
@@ -115,11 +183,245 @@ func (m *Match) Name(name string) *String {
 			"\nsubmatch name not found:\n %q\n\navailable names:\n%s",
 			name,
 			dumpValue(m.names))
-		return &String{m.chain, ""}
+		return &String{m.chain.clone(), ""}
 	}
 	return m.Index(index)
 }
 
+// NameOr is similar to Name, but returns a new String object with given
+// fallback instead of reporting failure if there is no submatch with given
+// name, or if the submatch is empty.
+//
+// Note that regexp doesn't distinguish between a group that didn't
+// participate in the match and a group that matched an empty string; in
+// both cases the submatch is empty, and fallback is used.
+//
+// Example:
+//   s := "http://example.com/users"
+//
+//   r := regexp.MustCompile(`http://(?P<host>[^/]+)/users(?:/(?P<user>.+))?`)
+//   m := NewMatch(t, r.FindStringSubmatch(s), r.SubexpNames())
+//
+//   m.NameOr("host", "localhost").Equal("example.com")
+//   m.NameOr("user", "anonymous").Equal("anonymous")
+func (m *Match) NameOr(name, fallback string) *String {
+	if m.chain.failed() {
+		return &String{m.chain.clone(), ""}
+	}
+	index, ok := m.names[name]
+	if !ok || index >= len(m.submatches) || m.submatches[index] == "" {
+		return &String{m.chain.clone(), fallback}
+	}
+	return &String{m.chain.clone(), m.submatches[index]}
+}
+
+// Replace expands given template against submatches and returns a new
+// String object with the result.
+//
+// Template follows regexp.Expand rules: $name or ${name} is replaced by
+// submatch with given name or index, $$ is replaced by a literal $, and
+// in the $name form, name is taken to be as long as possible, i.e. $1x is
+// equivalent to ${1x}, not ${1}x.
+//
+// Unlike regexp.Expand, if template refers to a submatch that doesn't
+// exist, Replace reports failure and returns empty (but non-nil) value.
+//
+// Example:
+//   s := "http://example.com/users/john"
+//
+//   r := regexp.MustCompile(`http://(?P<host>.+)/users/(?P<user>.+)`)
+//   m := NewMatch(t, r.FindStringSubmatch(s), r.SubexpNames())
+//
+//   m.Replace("${user}@$host").Equal("john@example.com")
+//   m.Replace("$2 at $1").Equal("john at example.com")
+func (m *Match) Replace(template string) *String {
+	if m.chain.failed() {
+		return &String{m.chain.clone(), ""}
+	}
+
+	var result strings.Builder
+
+	for {
+		i := strings.IndexByte(template, '$')
+		if i < 0 {
+			break
+		}
+		result.WriteString(template[:i])
+		template = template[i:]
+
+		if len(template) > 1 && template[1] == '$' {
+			result.WriteByte('$')
+			template = template[2:]
+			continue
+		}
+
+		name, rest, ok := extractTemplateName(template)
+		if !ok {
+			result.WriteByte('$')
+			template = template[1:]
+			continue
+		}
+		template = rest
+
+		index, err := strconv.Atoi(name)
+		if err != nil {
+			var found bool
+			if index, found = m.names[name]; !found {
+				index = -1
+			}
+		}
+
+		if index < 0 || index >= len(m.submatches) {
+			m.chain.failWith(failure{
+				assertType: "match",
+				assertion:  "expected template referring to existing submatches",
+				got:        "but got reference to",
+				actual:     fmt.Sprintf(" %q", name),
+				details: fmt.Sprintf(
+					"\n\nsubmatches:\n%s\n\nnames:\n%s",
+					dumpValue(m.submatches), dumpValue(m.names)),
+			})
+			return &String{m.chain.clone(), ""}
+		}
+
+		result.WriteString(m.submatches[index])
+	}
+
+	result.WriteString(template)
+
+	return &String{m.chain.clone(), result.String()}
+}
+
+// extractTemplateName parses $name or ${name} at the beginning of template
+// the same way as regexp.Expand does.
+func extractTemplateName(template string) (name, rest string, ok bool) {
+	if len(template) < 2 || template[0] != '$' {
+		return "", "", false
+	}
+
+	brace := template[1] == '{'
+	if brace {
+		template = template[2:]
+	} else {
+		template = template[1:]
+	}
+
+	i := 0
+	for i < len(template) {
+		c := template[i]
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+			c >= '0' && c <= '9') {
+			break
+		}
+		i++
+	}
+	if i == 0 {
+		return "", "", false
+	}
+
+	name, rest = template[:i], template[i:]
+	if brace {
+		if len(rest) == 0 || rest[0] != '}' {
+			return "", "", false
+		}
+		rest = rest[1:]
+	}
+
+	return name, rest, true
+}
+
+// Decode populates fields of given struct from named submatches.
+//
+// target should be a non-nil pointer to struct. Every exported field is
+// filled from the submatch with the same name as the field, or with the
+// name given in `match:"name"` tag. Fields with `match:"-"` tag are skipped.
+//
+// Fields may have string, bool, integer, or floating point type. Submatch
+// values are converted to the field type using strconv package. Named
+// submatches without corresponding field are ignored.
+//
+// If target has wrong type, if there is no submatch for some fields, or if
+// submatch can't be converted to the field type, Decode reports failure
+// and leaves target unmodified. All fields without submatches are listed
+// in the failure message.
+//
+// Example:
+//   type User struct {
+//       Host string
+//       ID   int `match:"id"`
+//   }
+//
+//   s := "http://example.com/users/42"
+//   r := regexp.MustCompile(`http://(?P<Host>.+)/users/(?P<id>\d+)`)
+//   m := NewMatch(t, r.FindStringSubmatch(s), r.SubexpNames())
+//
+//   var user User
+//   m.Decode(&user)
+//
+//   assert.Equal(t, User{Host: "example.com", ID: 42}, user)
+func (m *Match) Decode(target interface{}) *Match {
+	if m.chain.failed() {
+		return m
+	}
+
+	ptr := reflect.ValueOf(target)
+	if target == nil || ptr.Kind() != reflect.Ptr || ptr.IsNil() ||
+		ptr.Elem().Kind() != reflect.Struct {
+		m.chain.fail(
+			"\nexpected non-nil pointer to struct passed to Decode, but got:\n %T",
+			target)
+		return m
+	}
+
+	result := reflect.New(ptr.Elem().Type()).Elem()
+	result.Set(ptr.Elem())
+
+	var missing []string
+
+	for i := 0; i < result.NumField(); i++ {
+		field := result.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("match"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		index, ok := m.names[name]
+		if !ok || index >= len(m.submatches) {
+			missing = append(missing, fmt.Sprintf("%s (%q)", field.Name, name))
+			continue
+		}
+
+		if err := setMatchField(result.Field(i), m.submatches[index]); err != nil {
+			m.chain.fail(
+				"\ncan't decode submatch %q into field %s:\n %s",
+				name,
+				field.Name,
+				err.Error())
+			return m
+		}
+	}
+
+	if len(missing) != 0 {
+		m.chain.fail(
+			"\nsubmatches not found for fields:\n %s\n\navailable names:\n%s",
+			strings.Join(missing, "\n "),
+			dumpValue(m.names))
+		return m
+	}
+
+	ptr.Elem().Set(result)
+	return m
+}
+
 // Empty succeeds if submatches array is empty.
 //
 // Example:
@@ -127,8 +429,11 @@ func (m *Match) Name(name string) *String {
 //  m.Empty()
 func (m *Match) Empty() *Match {
 	if len(m.submatches) != 0 {
-		m.chain.fail("\nexpected zero submatches, but got:\n  %s",
-			dumpValue(m.submatches))
+		m.chain.failWith(failure{
+			assertType: "match",
+			assertion:  "expected zero submatches",
+			actual:     dumpValue(m.submatches),
+		})
 	}
 	return m
 }
@@ -140,7 +445,10 @@ func (m *Match) Empty() *Match {
 //  m.NotEmpty()
 func (m *Match) NotEmpty() *Match {
 	if len(m.submatches) == 0 {
-		m.chain.fail("expected non-zero submatches")
+		m.chain.failWith(failure{
+			assertType: "match",
+			assertion:  "expected non-zero submatches",
+		})
 	}
 	return m
 }
@@ -161,9 +469,12 @@ func (m *Match) Values(values ...string) *Match {
 		values = []string{}
 	}
 	if !reflect.DeepEqual(values, m.getValues()) {
-		m.chain.fail("\nexpected submatches equal to:\n%s\n\nbut got:\n%s",
-			dumpValue(values),
-			dumpValue(m.getValues()))
+		m.chain.failWith(failure{
+			assertType: "match",
+			assertion:  "expected submatches equal to",
+			expected:   dumpValue(values),
+			actual:     dumpValue(m.getValues()),
+		})
 	}
 	return m
 }
@@ -184,8 +495,11 @@ func (m *Match) NotValues(values ...string) *Match {
 		values = []string{}
 	}
 	if reflect.DeepEqual(values, m.getValues()) {
-		m.chain.fail("\nexpected submatches not equal to:\n%s",
-			dumpValue(values))
+		m.chain.failWith(failure{
+			assertType: "match",
+			assertion:  "expected submatches not equal to",
+			expected:   dumpValue(values),
+		})
 	}
 	return m
 }
@@ -196,3 +510,43 @@ func (m *Match) getValues() []string {
 	}
 	return []string{}
 }
+
+func setMatchField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,13 +17,20 @@ func TestMatchFailed(t *testing.T) {
 	value.chain.assertFailed(t)
 
 	assert.False(t, value.Length() == nil)
+	assert.False(t, value.NamedLength() == nil)
 	assert.False(t, value.Index(0) == nil)
 	assert.False(t, value.Name("") == nil)
 
 	value.Length().chain.assertFailed(t)
+	value.NamedLength().chain.assertFailed(t)
 	value.Index(0).chain.assertFailed(t)
 	value.Name("").chain.assertFailed(t)
+	value.NameOr("", "").chain.assertFailed(t)
+	value.LengthMin(0).chain.assertFailed(t)
+	value.LengthMax(0).chain.assertFailed(t)
+	value.Replace("").chain.assertFailed(t)
 
+	value.Decode(&struct{}{})
 	value.Empty()
 	value.NotEmpty()
 	value.Values("")
@@ -67,6 +75,210 @@ func TestMatchGetters(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestMatchLengthMinMax(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewMatch(reporter, []string{"m", "1", "2"}, nil)
+
+	value.LengthMin(0).chain.assertOK(t)
+	value.LengthMin(3).chain.assertOK(t)
+	value.LengthMax(3).chain.assertOK(t)
+	value.LengthMax(10).chain.assertOK(t)
+
+	value.LengthMin(4).chain.assertFailed(t)
+	value.chain.reset()
+
+	value.LengthMax(2).chain.assertFailed(t)
+	value.chain.reset()
+
+	empty := NewMatch(reporter, nil, nil)
+
+	empty.LengthMax(0).chain.assertOK(t)
+	empty.LengthMin(1).chain.assertFailed(t)
+}
+
+func TestMatchNameOr(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	r := regexp.MustCompile(`http://(?P<host>[^/]*)/users(?:/(?P<user>.+))?`)
+
+	m1 := NewMatch(reporter,
+		r.FindStringSubmatch("http://example.com/users/john"), r.SubexpNames())
+
+	assert.Equal(t, "example.com", m1.NameOr("host", "localhost").Raw())
+	assert.Equal(t, "john", m1.NameOr("user", "anonymous").Raw())
+	assert.Equal(t, "default", m1.NameOr("missing", "default").Raw())
+	m1.chain.assertOK(t)
+
+	m2 := NewMatch(reporter,
+		r.FindStringSubmatch("http:///users"), r.SubexpNames())
+
+	assert.Equal(t, "localhost", m2.NameOr("host", "localhost").Raw())
+	assert.Equal(t, "anonymous", m2.NameOr("user", "anonymous").Raw())
+	m2.chain.assertOK(t)
+
+	m3 := NewMatch(reporter, nil, r.SubexpNames())
+
+	assert.Equal(t, "localhost", m3.NameOr("host", "localhost").Raw())
+	m3.chain.assertOK(t)
+}
+
+func TestMatchReplace(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	r := regexp.MustCompile(`http://(?P<host>.+)/users/(?P<user>.+)`)
+
+	s := "http://example.com/users/john"
+	m := NewMatch(reporter, r.FindStringSubmatch(s), r.SubexpNames())
+
+	cases := map[string]string{
+		"":                     "",
+		"plain":                "plain",
+		"${user}@$host":        "john@example.com",
+		"$2 at $1":             "john at example.com",
+		"${1}x":                "example.comx",
+		"$0":                   "http://example.com/users/john",
+		"$$1 costs $":          "$1 costs $",
+		"${user":               "${user",
+		"$-":                   "$-",
+		"https://${host}/u/$2": "https://example.com/u/john",
+	}
+
+	for template, expected := range cases {
+		m.Replace(template).Equal(expected).chain.assertOK(t)
+		assert.Equal(t, expected,
+			string(r.ExpandString(nil, template, s, r.FindStringSubmatchIndex(s))))
+	}
+
+	for _, template := range []string{"$3", "${missing}", "$1x", "$hostname"} {
+		m := NewMatch(reporter, r.FindStringSubmatch(s), r.SubexpNames())
+		replaced := m.Replace(template)
+		m.chain.assertFailed(t)
+		replaced.chain.assertFailed(t)
+		assert.Equal(t, "", replaced.Raw())
+	}
+}
+
+func TestMatchNamedLength(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	r := regexp.MustCompile(`(?P<host>[a-z.]+)/(\w+)(?P<port>:\d+)?(?P<path>/.*)?`)
+
+	value1 := NewMatch(reporter, r.FindStringSubmatch("example.com/users"),
+		r.SubexpNames())
+
+	assert.Equal(t, 5.0, value1.Length().Raw())
+	assert.Equal(t, 1.0, value1.NamedLength().Raw())
+	value1.chain.assertOK(t)
+
+	value2 := NewMatch(reporter, r.FindStringSubmatch("example.com/users/john"),
+		r.SubexpNames())
+
+	assert.Equal(t, 2.0, value2.NamedLength().Raw())
+	value2.chain.assertOK(t)
+
+	value3 := NewMatch(reporter, []string{"m0", "m1"}, nil)
+
+	assert.Equal(t, 0.0, value3.NamedLength().Raw())
+	value3.chain.assertOK(t)
+
+	value4 := NewMatch(reporter, nil, []string{"", "n1"})
+
+	assert.Equal(t, 0.0, value4.NamedLength().Raw())
+	value4.chain.assertOK(t)
+}
+
+func TestMatchDecode(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	r := regexp.MustCompile(
+		`/(?P<Host>[^/]+)/(?P<id>-?\d+)/(?P<score>[\d.]+)/(?P<admin>\w+)/(?P<n>\d+)`)
+
+	match := func(s string) *Match {
+		return NewMatch(reporter, r.FindStringSubmatch(s), r.SubexpNames())
+	}
+
+	type User struct {
+		Host    string
+		ID      int     `match:"id"`
+		Score   float64 `match:"score"`
+		Admin   bool    `match:"admin"`
+		N       uint8   `match:"n"`
+		Skipped string  `match:"-"`
+	}
+
+	var user User
+	value := match("/example.com/-42/1.5/true/7")
+	value.Decode(&user)
+	value.chain.assertOK(t)
+
+	assert.Equal(t, User{
+		Host:  "example.com",
+		ID:    -42,
+		Score: 1.5,
+		Admin: true,
+		N:     7,
+	}, user)
+
+	cases := []string{
+		"/example.com/42/1.5/maybe/7",
+		"/example.com/42/1.5.5/true/7",
+		"/example.com/42/1.5/true/300",
+	}
+
+	for _, s := range cases {
+		user := User{Host: "original"}
+		value := match(s)
+		value.Decode(&user)
+		value.chain.assertFailed(t)
+		assert.Equal(t, User{Host: "original"}, user)
+	}
+
+	var missing struct {
+		Host  string
+		Other string
+		Port  int `match:"port"`
+	}
+	value = match("/example.com/42/1.5/true/7")
+	value.Decode(&missing)
+	value.chain.assertFailed(t)
+
+	assert.Contains(t, reporter.message, `Other ("Other")`)
+	assert.Contains(t, reporter.message, `Port ("port")`)
+
+	var partial struct {
+		Host string
+	}
+	value = match("/example.com/42/1.5/true/7")
+	value.Decode(&partial)
+	value.chain.assertOK(t)
+	assert.Equal(t, "example.com", partial.Host)
+
+	var unsupported struct {
+		Host []string
+	}
+	value = match("/example.com/42/1.5/true/7")
+	value.Decode(&unsupported)
+	value.chain.assertFailed(t)
+
+	value = match("/example.com/42/1.5/true/7")
+	value.Decode(user)
+	value.chain.assertFailed(t)
+
+	value = match("/example.com/42/1.5/true/7")
+	value.Decode(nil)
+	value.chain.assertFailed(t)
+
+	var str string
+	value = match("/example.com/42/1.5/true/7")
+	value.Decode(&str)
+	value.chain.assertFailed(t)
+
+	value = match("no match")
+	value.Decode(&User{})
+	value.chain.assertFailed(t)
+}
+
 func TestMatchEmpty(t *testing.T) {
 	reporter := newMockReporter(t)
 
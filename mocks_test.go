@@ -1,6 +1,9 @@
 package httpexpect
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 )
@@ -21,16 +24,39 @@ func (c *mockClient) Do(req *http.Request) (*http.Response, error) {
 	return nil, c.err
 }
 
+type mockClientFunc func(req *http.Request) (*http.Response, error)
+
+func (f mockClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
 type mockReporter struct {
 	testing  *testing.T
 	reported bool
+	message  string
 }
 
 func newMockReporter(t *testing.T) *mockReporter {
-	return &mockReporter{t, false}
+	return &mockReporter{testing: t}
 }
 
 func (r *mockReporter) Errorf(message string, args ...interface{}) {
 	r.testing.Logf("Fail: "+message, args...)
 	r.reported = true
+	r.message = fmt.Sprintf(message, args...)
+}
+
+type mockJSONDecoder struct {
+	err   error
+	calls int
+}
+
+func (d *mockJSONDecoder) Unmarshal(data []byte, v interface{}) error {
+	d.calls++
+	if d.err != nil {
+		return d.err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
 }
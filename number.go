@@ -1,14 +1,22 @@
 package httpexpect
 
 import (
+	"fmt"
 	"math"
+	"math/big"
 )
 
 // Number provides methods to inspect attached float64 value
 // (Go representation of JSON number).
+//
+// If Config.PreserveNumbers is enabled, Number obtained from a JSON integer
+// that can't be represented exactly as float64 also keeps its exact value,
+// which is used by Equal and NotEqual. Other methods use float64
+// approximation.
 type Number struct {
 	chain chain
 	value float64
+	exact *big.Int
 }
 
 // NewNumber returns a new Number given a reporter used to report
@@ -19,7 +27,7 @@ type Number struct {
 // Example:
 //  number := NewNumber(t, 123.4)
 func NewNumber(reporter Reporter, value float64) *Number {
-	return &Number{makeChain(reporter), value}
+	return &Number{makeChain(reporter), value, nil}
 }
 
 // Raw returns underlying value attached to Number.
@@ -39,27 +47,43 @@ func (n *Number) Path(path string) *Value {
 
 // Schema is similar to Value.Schema.
 func (n *Number) Schema(schema interface{}) *Number {
-	checkSchema(&n.chain, n.value, schema)
+	checkSchema(&n.chain, "number", n.value, schema)
 	return n
 }
 
 // Equal succeeds if number is equal to given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// it is converted to float64. If number holds exact value (see
+// Config.PreserveNumbers), integers are compared exactly instead.
 //
 // Example:
 //  number := NewNumber(t, 123)
 //  number.Equal(float64(123))
 //  number.Equal(int32(123))
 func (n *Number) Equal(value interface{}) *Number {
+	if n.exact != nil {
+		if equal, ok := n.equalExact(value); ok && !equal {
+			n.chain.failWith(failure{
+				assertType: "number",
+				assertion:  "expected number equal to",
+				expected:   fmt.Sprintf(" %v", value),
+				actual:     fmt.Sprintf(" %v", n.exact),
+			})
+		}
+		return n
+	}
 	v, ok := canonNumber(&n.chain, value)
 	if !ok {
 		return n
 	}
 	if !(n.value == v) {
-		n.chain.fail("\nexpected number equal to:\n %v\n\nbut got:\n %v",
-			v, n.value)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number equal to",
+			expected:   fmt.Sprintf(" %v", v),
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
 	}
 	return n
 }
@@ -67,24 +91,51 @@ func (n *Number) Equal(value interface{}) *Number {
 // NotEqual succeeds if number is not equal to given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// it is converted to float64. If number holds exact value (see
+// Config.PreserveNumbers), integers are compared exactly instead.
 //
 // Example:
 //  number := NewNumber(t, 123)
 //  number.NotEqual(float64(321))
 //  number.NotEqual(int32(321))
 func (n *Number) NotEqual(value interface{}) *Number {
+	if n.exact != nil {
+		if equal, ok := n.equalExact(value); ok && equal {
+			n.chain.failWith(failure{
+				assertType: "number",
+				assertion:  "expected number not equal to",
+				expected:   fmt.Sprintf(" %v", value),
+				actual:     fmt.Sprintf(" %v", n.exact),
+			})
+		}
+		return n
+	}
 	v, ok := canonNumber(&n.chain, value)
 	if !ok {
 		return n
 	}
 	if !(n.value != v) {
-		n.chain.fail("\nexpected number not equal to:\n %v\n\nbut got:\n %v",
-			v, n.value)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number not equal to",
+			expected:   fmt.Sprintf(" %v", v),
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
 	}
 	return n
 }
 
+func (n *Number) equalExact(value interface{}) (equal bool, ok bool) {
+	if i, ok := exactInteger(value); ok {
+		return n.exact.Cmp(i) == 0, true
+	}
+	if _, ok := canonNumber(&n.chain, value); !ok {
+		return false, false
+	}
+	// exact number is an integer, so it's never equal to a non-integer
+	return false, true
+}
+
 // EqualDelta succeeds if two numerals are within delta of each other.
 //
 // Example:
@@ -92,16 +143,27 @@ func (n *Number) NotEqual(value interface{}) *Number {
 //  number.EqualDelta(123.2, 0.3)
 func (n *Number) EqualDelta(value, delta float64) *Number {
 	if math.IsNaN(n.value) || math.IsNaN(value) || math.IsNaN(delta) {
-		n.chain.fail("\nexpected number equal to:\n %v\n\nbut got:\n %v\n\ndelta:\n %v",
-			value, n.value, delta)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number equal to",
+			expected:   fmt.Sprintf(" %v", value),
+			actual:     fmt.Sprintf(" %v", n.value),
+			details:    fmt.Sprintf("\n\ndelta:\n %v", delta),
+		})
 		return n
 	}
 
 	diff := (n.value - value)
 
 	if diff < -delta || diff > delta {
-		n.chain.fail("\nexpected number equal to:\n %v\n\nbut got:\n %v\n\ndelta:\n %v",
-			value, n.value, delta)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number equal to",
+			expected:   fmt.Sprintf(" %v", value),
+			actual:     fmt.Sprintf(" %v", n.value),
+			details: fmt.Sprintf(
+				"\n\ndelta:\n %v\n\ndifference:\n %v", delta, math.Abs(diff)),
+		})
 		return n
 	}
 
@@ -115,24 +177,83 @@ func (n *Number) EqualDelta(value, delta float64) *Number {
 //  number.NotEqualDelta(123.2, 0.1)
 func (n *Number) NotEqualDelta(value, delta float64) *Number {
 	if math.IsNaN(n.value) || math.IsNaN(value) || math.IsNaN(delta) {
-		n.chain.fail(
-			"\nexpected number not equal to:\n %v\n\nbut got:\n %v\n\ndelta:\n %v",
-			value, n.value, delta)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number not equal to",
+			expected:   fmt.Sprintf(" %v", value),
+			actual:     fmt.Sprintf(" %v", n.value),
+			details:    fmt.Sprintf("\n\ndelta:\n %v", delta),
+		})
 		return n
 	}
 
 	diff := (n.value - value)
 
 	if !(diff < -delta || diff > delta) {
-		n.chain.fail(
-			"\nexpected number not equal to:\n %v\n\nbut got:\n %v\n\ndelta:\n %v",
-			value, n.value, delta)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number not equal to",
+			expected:   fmt.Sprintf(" %v", value),
+			actual:     fmt.Sprintf(" %v", n.value),
+			details: fmt.Sprintf(
+				"\n\ndelta:\n %v\n\ndifference:\n %v", delta, math.Abs(diff)),
+		})
 		return n
 	}
 
 	return n
 }
 
+// EqualRelative succeeds if number is within given fraction of value,
+// i.e. if |number - value| <= fraction * |value|.
+//
+// Example:
+//  number := NewNumber(t, 101.0)
+//  number.EqualRelative(100.0, 0.01)  // within 1%
+func (n *Number) EqualRelative(value, fraction float64) *Number {
+	delta := math.Abs(fraction * value)
+	diff := math.Abs(n.value - value)
+
+	if math.IsNaN(diff) || math.IsNaN(delta) || fraction < 0 || diff > delta {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number equal to",
+			expected:   fmt.Sprintf(" %v", value),
+			actual:     fmt.Sprintf(" %v", n.value),
+			details: fmt.Sprintf(
+				"\n\nrelative tolerance:\n %v (delta %v)\n\ndifference:\n %v",
+				fraction, delta, diff),
+		})
+	}
+
+	return n
+}
+
+// NotEqualRelative succeeds if number is not within given fraction of value,
+// i.e. if |number - value| > fraction * |value|.
+//
+// Example:
+//  number := NewNumber(t, 110.0)
+//  number.NotEqualRelative(100.0, 0.01)  // not within 1%
+func (n *Number) NotEqualRelative(value, fraction float64) *Number {
+	delta := math.Abs(fraction * value)
+	diff := math.Abs(n.value - value)
+
+	if math.IsNaN(diff) || math.IsNaN(delta) || fraction < 0 || !(diff > delta) {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number not equal to",
+			expected:   fmt.Sprintf(" %v", value),
+			actual:     fmt.Sprintf(" %v", n.value),
+			details: fmt.Sprintf(
+				"\n\nrelative tolerance:\n %v (delta %v)\n\ndifference:\n %v",
+				fraction, delta, diff),
+		})
+	}
+
+	return n
+}
+
 // Gt succeeds if number is greater than given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
@@ -148,8 +269,12 @@ func (n *Number) Gt(value interface{}) *Number {
 		return n
 	}
 	if !(n.value > v) {
-		n.chain.fail("\nexpected number > then:\n %v\n\nbut got:\n %v",
-			v, n.value)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number > then",
+			expected:   fmt.Sprintf(" %v", v),
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
 	}
 	return n
 }
@@ -169,8 +294,12 @@ func (n *Number) Ge(value interface{}) *Number {
 		return n
 	}
 	if !(n.value >= v) {
-		n.chain.fail("\nexpected number >= then:\n %v\n\nbut got:\n %v",
-			v, n.value)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number >= then",
+			expected:   fmt.Sprintf(" %v", v),
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
 	}
 	return n
 }
@@ -190,8 +319,12 @@ func (n *Number) Lt(value interface{}) *Number {
 		return n
 	}
 	if !(n.value < v) {
-		n.chain.fail("\nexpected number < then:\n %v\n\nbut got:\n %v",
-			v, n.value)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number < then",
+			expected:   fmt.Sprintf(" %v", v),
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
 	}
 	return n
 }
@@ -211,8 +344,12 @@ func (n *Number) Le(value interface{}) *Number {
 		return n
 	}
 	if !(n.value <= v) {
-		n.chain.fail("\nexpected number <= then:\n %v\n\nbut got:\n %v",
-			v, n.value)
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number <= then",
+			expected:   fmt.Sprintf(" %v", v),
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
 	}
 	return n
 }
@@ -227,18 +364,313 @@ func (n *Number) Le(value interface{}) *Number {
 //  number.InRange(float32(100), int32(200))  // success
 //  number.InRange(100, 200)                  // success
 //  number.InRange(123, 123)                  // success
+//
+// If min is greater than max, InRange reports failure regardless of
+// the number value.
 func (n *Number) InRange(min, max interface{}) *Number {
-	a, ok := canonNumber(&n.chain, min)
+	a, b, ok := n.canonRange(min, max)
 	if !ok {
 		return n
 	}
-	b, ok := canonNumber(&n.chain, max)
+	if !(n.value >= a && n.value <= b) {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number in range",
+			expected:   fmt.Sprintf(" [%v; %v]", a, b),
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
+	}
+	return n
+}
+
+// NotInRange succeeds if number is not in given range [min; max].
+//
+// min and max should have numeric type convertible to float64. Before comparison,
+// they are converted to float64.
+//
+// If min is greater than max, NotInRange reports failure regardless of
+// the number value.
+//
+// Example:
+//  number := NewNumber(t, 100)
+//  number.NotInRange(0, 99)    // success
+//  number.NotInRange(101, 200) // success
+//  number.NotInRange(0, 100)   // failure
+func (n *Number) NotInRange(min, max interface{}) *Number {
+	a, b, ok := n.canonRange(min, max)
 	if !ok {
 		return n
 	}
-	if !(n.value >= a && n.value <= b) {
-		n.chain.fail("\nexpected number in range:\n [%v; %v]\n\nbut got:\n %v",
-			a, b, n.value)
+	if n.value >= a && n.value <= b {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number not in range",
+			expected:   fmt.Sprintf(" [%v; %v]", a, b),
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
+	}
+	return n
+}
+
+func (n *Number) canonRange(min, max interface{}) (a, b float64, ok bool) {
+	if a, ok = canonNumber(&n.chain, min); !ok {
+		return
+	}
+	if b, ok = canonNumber(&n.chain, max); !ok {
+		return
+	}
+	if a > b {
+		n.chain.fail(
+			"\nexpected range with min less than or equal to max, but got:\n [%v; %v]",
+			a, b)
+		return a, b, false
+	}
+	return a, b, true
+}
+
+// maxSafeInteger is the largest integer that float64 represents exactly,
+// as well as all integers below it (2^53).
+const maxSafeInteger = 1 << 53
+
+// IsInteger succeeds if number is a whole number, i.e. has no fractional part.
+//
+// Numbers which absolute value exceeds 2^53 are not treated as integers,
+// because float64 can't represent every integer in this range, and so
+// such values may be already rounded. NaN and infinity are not treated
+// as integers as well.
+//
+// Example:
+//  number := NewNumber(t, 100)
+//  number.IsInteger()
+func (n *Number) IsInteger() *Number {
+	n.checkInteger()
+	return n
+}
+
+// IsInt succeeds if number is a whole number and, if bits is given,
+// fits into signed integer of given bit size.
+//
+// bits should be in range [1; 64]. Without bits, IsInt is equivalent to
+// IsInteger. Note that float64 can't represent every integer above 2^53,
+// so such numbers are never treated as integers, even if bits is 64.
+//
+// Example:
+//  number := NewNumber(t, 1000)
+//  number.IsInt()    // success
+//  number.IsInt(32)  // success
+//  number.IsInt(8)   // failure
+func (n *Number) IsInt(bits ...int) *Number {
+	if len(bits) > 1 {
+		n.chain.fail("\nunexpected multiple bits arguments passed to IsInt")
+		return n
+	}
+	if len(bits) != 0 && (bits[0] < 1 || bits[0] > 64) {
+		n.chain.fail("\nunexpected bits argument passed to IsInt:\n %d"+
+			"\n\nexpected value in range:\n [1; 64]", bits[0])
+		return n
+	}
+	if !n.checkInteger() {
+		return n
+	}
+	if len(bits) != 0 {
+		min := -math.Ldexp(1, bits[0]-1)
+		max := math.Ldexp(1, bits[0]-1) - 1
+		if n.value < min || n.value > max {
+			n.chain.failWith(failure{
+				assertType: "number",
+				assertion: fmt.Sprintf(
+					"expected %d-bit integer number in range", bits[0]),
+				expected: fmt.Sprintf(" [%v; %v]", min, max),
+				actual:   fmt.Sprintf(" %v", n.value),
+			})
+		}
+	}
+	return n
+}
+
+func (n *Number) checkInteger() bool {
+	if math.IsNaN(n.value) || math.IsInf(n.value, 0) {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected integer number",
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
+		return false
+	}
+	if math.Abs(n.value) > maxSafeInteger {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected integer number",
+			got:        "but got number out of exact range",
+			actual:     fmt.Sprintf(" %v", n.value),
+			details: fmt.Sprintf(
+				"\n\nexact range:\n [%v; %v]", -maxSafeInteger, maxSafeInteger),
+		})
+		return false
+	}
+	if math.Trunc(n.value) != n.value {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected integer number",
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
+		return false
+	}
+	return true
+}
+
+// NotInteger succeeds if number has fractional part.
+//
+// NotInteger also succeeds for NaN and infinity. It fails for numbers
+// which absolute value exceeds 2^53, because float64 can't tell whether
+// they had fractional part.
+//
+// Example:
+//  number := NewNumber(t, 100.5)
+//  number.NotInteger()
+func (n *Number) NotInteger() *Number {
+	if math.IsNaN(n.value) || math.IsInf(n.value, 0) {
+		return n
+	}
+	if math.Abs(n.value) > maxSafeInteger {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected non-integer number",
+			got:        "but got number out of exact range",
+			actual:     fmt.Sprintf(" %v", n.value),
+			details: fmt.Sprintf(
+				"\n\nexact range:\n [%v; %v]", -maxSafeInteger, maxSafeInteger),
+		})
+		return n
+	}
+	if math.Trunc(n.value) == n.value {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected non-integer number",
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
+	}
+	return n
+}
+
+// multipleTolerance is relative tolerance of remainder used by IsMultipleOf.
+const multipleTolerance = 1e-9
+
+// IsMultipleOf succeeds if number is an integer multiple of divisor.
+//
+// To tolerate floating point errors, e.g. for 0.3 and divisor 0.1, the
+// remainder is compared with zero with relative tolerance 1e-9 of divisor.
+// divisor should be finite and non-zero. NaN and infinity are not multiples
+// of any divisor.
+//
+// Example:
+//  number := NewNumber(t, 100)
+//  number.IsMultipleOf(20)
+//  number.IsMultipleOf(2)  // even
+func (n *Number) IsMultipleOf(divisor float64) *Number {
+	if divisor == 0 || math.IsNaN(divisor) || math.IsInf(divisor, 0) {
+		n.chain.fail("\nunexpected divisor argument passed to IsMultipleOf:\n %v"+
+			"\n\nexpected finite non-zero number", divisor)
+		return n
+	}
+	remainder := math.Remainder(n.value, divisor)
+	if math.IsNaN(remainder) ||
+		math.Abs(remainder) > multipleTolerance*math.Abs(divisor) {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected number multiple of",
+			expected:   fmt.Sprintf(" %v", divisor),
+			actual:     fmt.Sprintf(" %v", n.value),
+			details:    fmt.Sprintf("\n\nremainder:\n %v", remainder),
+		})
+	}
+	return n
+}
+
+// IsFinite succeeds if number is neither NaN nor infinity.
+//
+// Example:
+//  number := NewNumber(t, 123)
+//  number.IsFinite()
+func (n *Number) IsFinite() *Number {
+	if math.IsNaN(n.value) || math.IsInf(n.value, 0) {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected finite number",
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
+	}
+	return n
+}
+
+// IsPositive succeeds if number is greater than zero.
+//
+// Zero (including negative zero) and NaN are not positive, +Inf is positive.
+//
+// Example:
+//  number := NewNumber(t, 123)
+//  number.IsPositive()
+func (n *Number) IsPositive() *Number {
+	if !(n.value > 0) {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected positive number",
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
+	}
+	return n
+}
+
+// IsNegative succeeds if number is less than zero.
+//
+// Zero (including negative zero) and NaN are not negative, -Inf is negative.
+//
+// Example:
+//  number := NewNumber(t, -123)
+//  number.IsNegative()
+func (n *Number) IsNegative() *Number {
+	if !(n.value < 0) {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected negative number",
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
+	}
+	return n
+}
+
+// IsZero succeeds if number is equal to zero.
+//
+// Both positive and negative zero are accepted, NaN is not zero.
+//
+// Example:
+//  number := NewNumber(t, 0)
+//  number.IsZero()
+func (n *Number) IsZero() *Number {
+	if !(n.value == 0) {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected zero number",
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
+	}
+	return n
+}
+
+// IsNonNegative succeeds if number is greater than or equal to zero.
+//
+// Negative zero is accepted as zero, NaN is not accepted.
+//
+// Example:
+//  number := NewNumber(t, 0)
+//  number.IsNonNegative()
+func (n *Number) IsNonNegative() *Number {
+	if !(n.value >= 0) {
+		n.chain.failWith(failure{
+			assertType: "number",
+			assertion:  "expected non-negative number",
+			actual:     fmt.Sprintf(" %v", n.value),
+		})
 	}
 	return n
 }
@@ -1,7 +1,9 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,7 +14,7 @@ func TestNumberFailed(t *testing.T) {
 
 	chain.fail("fail")
 
-	value := &Number{chain, 0}
+	value := &Number{chain, 0, nil}
 
 	value.chain.assertFailed(t)
 
@@ -21,11 +23,25 @@ func TestNumberFailed(t *testing.T) {
 
 	value.Equal(0)
 	value.NotEqual(0)
+	value.EqualDelta(0, 0)
+	value.NotEqualDelta(0, 0)
+	value.EqualRelative(0, 0)
+	value.NotEqualRelative(0, 0)
 	value.Gt(0)
 	value.Ge(0)
 	value.Lt(0)
 	value.Le(0)
 	value.InRange(0, 0)
+	value.NotInRange(0, 0)
+	value.IsInteger()
+	value.NotInteger()
+	value.IsInt()
+	value.IsMultipleOf(1)
+	value.IsFinite()
+	value.IsPositive()
+	value.IsNegative()
+	value.IsZero()
+	value.IsNonNegative()
 }
 
 func TestNumberGetters(t *testing.T) {
@@ -74,6 +90,54 @@ func TestNumberEqual(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestNumberEqualExact(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	exact, _ := new(big.Int).SetString("9007199254740993", 10)
+
+	value := &Number{makeChain(reporter), 9007199254740992, exact}
+
+	value.Equal(int64(9007199254740993))
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.Equal(uint64(9007199254740993))
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.Equal(json.Number("9007199254740993"))
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.Equal(int64(9007199254740992))
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.Equal(float64(9007199254740992))
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.Equal(9007199254740992.5)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.Equal("9007199254740993")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.NotEqual(int64(9007199254740992))
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.NotEqual(int64(9007199254740993))
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.NotEqual("9007199254740993")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
 func TestNumberEqualDelta(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -112,6 +176,85 @@ func TestNumberEqualDelta(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestNumberEqualRelative(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewNumber(reporter, 101)
+
+	value.EqualRelative(100, 0.01)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EqualRelative(100, 0.02)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EqualRelative(100, 0.005)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.EqualRelative(-100, 0.5)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.EqualRelative(100, -0.1)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.EqualRelative(100, math.NaN())
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.NotEqualRelative(100, 0.005)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.NotEqualRelative(100, 0.01)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.NotEqualRelative(100, math.NaN())
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	zero := NewNumber(reporter, 0)
+
+	zero.EqualRelative(0, 0.1)
+	zero.chain.assertOK(t)
+	zero.chain.reset()
+
+	zero.EqualRelative(0, 0)
+	zero.chain.assertOK(t)
+	zero.chain.reset()
+
+	nan := NewNumber(reporter, math.NaN())
+
+	nan.EqualRelative(100, 0.1)
+	nan.chain.assertFailed(t)
+	nan.chain.reset()
+
+	nan.NotEqualRelative(100, 0.1)
+	nan.chain.assertFailed(t)
+	nan.chain.reset()
+}
+
+func TestNumberEqualDeltaMessage(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewNumber(reporter, 1234.5)
+
+	value.EqualDelta(1234.0, 0.1)
+	value.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "difference:\n 0.5")
+
+	value.chain.reset()
+
+	value.EqualRelative(1000.0, 0.1)
+	value.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "relative tolerance:\n 0.1 (delta 100)")
+	assert.Contains(t, reporter.message, "difference:\n 234.5")
+}
+
 func TestNumberEqualNaN(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -228,6 +371,43 @@ func TestNumberInRange(t *testing.T) {
 	value.InRange(1234+1, 1234-1)
 	value.chain.assertFailed(t)
 	value.chain.reset()
+
+	assert.Contains(t, reporter.message, "but got:\n [1235; 1233]")
+	assert.NotContains(t, reporter.message, "InRange")
+}
+
+func TestNumberNotInRange(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewNumber(reporter, 1234)
+
+	value.NotInRange(1234+1, 1234+2)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.NotInRange(1234-2, 1234-1)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.NotInRange(1234, 1234)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.NotInRange(1234-1, 1234)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.NotInRange(1234, 1234+1)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.NotInRange(1234+1, 1234-1)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.NotInRange("1000", 2000)
+	value.chain.assertFailed(t)
+	value.chain.reset()
 }
 
 func TestNumberConvertEqual(t *testing.T) {
@@ -337,3 +517,198 @@ func TestNumberConvertInRange(t *testing.T) {
 	value.chain.assertFailed(t)
 	value.chain.reset()
 }
+
+func TestNumberIsInteger(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	for _, v := range []float64{0, 1, -1, 1234, -1234, 1 << 53, -(1 << 53)} {
+		value := NewNumber(reporter, v)
+
+		value.IsInteger()
+		value.chain.assertOK(t)
+		value.chain.reset()
+
+		value.NotInteger()
+		value.chain.assertFailed(t)
+		value.chain.reset()
+	}
+
+	for _, v := range []float64{0.5, -0.5, 1234.01, math.SmallestNonzeroFloat64} {
+		value := NewNumber(reporter, v)
+
+		value.IsInteger()
+		value.chain.assertFailed(t)
+		value.chain.reset()
+
+		value.NotInteger()
+		value.chain.assertOK(t)
+		value.chain.reset()
+	}
+
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		value := NewNumber(reporter, v)
+
+		value.IsInteger()
+		value.chain.assertFailed(t)
+		value.chain.reset()
+
+		value.NotInteger()
+		value.chain.assertOK(t)
+		value.chain.reset()
+	}
+
+	for _, v := range []float64{1<<53 + 2, -(1<<53 + 2), math.MaxFloat64} {
+		value := NewNumber(reporter, v)
+
+		value.IsInteger()
+		value.chain.assertFailed(t)
+		value.chain.reset()
+
+		value.NotInteger()
+		value.chain.assertFailed(t)
+		value.chain.reset()
+	}
+}
+
+func TestNumberIsInt(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		value float64
+		bits  []int
+		ok    bool
+	}{
+		{0, nil, true},
+		{-1234, nil, true},
+		{1234.5, nil, false},
+		{math.NaN(), nil, false},
+		{math.Inf(1), nil, false},
+		{127, []int{8}, true},
+		{-128, []int{8}, true},
+		{128, []int{8}, false},
+		{-129, []int{8}, false},
+		{math.MaxInt32, []int{32}, true},
+		{math.MinInt32, []int{32}, true},
+		{math.MaxInt32 + 1, []int{32}, false},
+		{math.MinInt32 - 1, []int{32}, false},
+		{1.5, []int{32}, false},
+		{1 << 53, []int{64}, true},
+		{1<<53 + 2, []int{64}, false},
+		{0, []int{1}, true},
+		{-1, []int{1}, true},
+		{1, []int{1}, false},
+		{0, []int{0}, false},
+		{0, []int{65}, false},
+		{0, []int{32, 64}, false},
+	}
+
+	for _, c := range cases {
+		value := NewNumber(reporter, c.value)
+		value.IsInt(c.bits...)
+		if c.ok {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+	}
+}
+
+func TestNumberIsMultipleOf(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	for _, tc := range []struct {
+		value   float64
+		divisor float64
+	}{
+		{100, 20},
+		{100, 2},
+		{0, 7},
+		{-9, 3},
+		{9, -3},
+		{0.3, 0.1},
+		{1.5, 0.5},
+		{1e15, 5},
+	} {
+		NewNumber(reporter, tc.value).IsMultipleOf(tc.divisor).chain.assertOK(t)
+	}
+
+	for _, tc := range []struct {
+		value   float64
+		divisor float64
+	}{
+		{101, 2},
+		{10, 3},
+		{0.35, 0.1},
+		{math.NaN(), 2},
+		{math.Inf(1), 2},
+	} {
+		NewNumber(reporter, tc.value).IsMultipleOf(tc.divisor).chain.assertFailed(t)
+	}
+
+	NewNumber(reporter, 10).IsMultipleOf(3).chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "remainder:\n 1")
+
+	NewNumber(reporter, 11).IsMultipleOf(3).chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "remainder:\n -1")
+
+	for _, divisor := range []float64{0, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		NewNumber(reporter, 10).IsMultipleOf(divisor).chain.assertFailed(t)
+		assert.Contains(t, reporter.message, "IsMultipleOf")
+	}
+}
+
+func TestNumberIsFinite(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	for _, v := range []float64{0, -1, 1234.5, math.MaxFloat64, -math.MaxFloat64} {
+		value := NewNumber(reporter, v)
+		value.IsFinite()
+		value.chain.assertOK(t)
+	}
+
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		value := NewNumber(reporter, v)
+		value.IsFinite()
+		value.chain.assertFailed(t)
+	}
+}
+
+func TestNumberSign(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		value       float64
+		positive    bool
+		negative    bool
+		zero        bool
+		nonNegative bool
+	}{
+		{1, true, false, false, true},
+		{0.001, true, false, false, true},
+		{math.Inf(1), true, false, false, true},
+		{-1, false, true, false, false},
+		{-0.001, false, true, false, false},
+		{math.Inf(-1), false, true, false, false},
+		{0, false, false, true, true},
+		{math.Copysign(0, -1), false, false, true, true},
+		{math.NaN(), false, false, false, false},
+	}
+
+	check := func(value *Number, ok bool) {
+		if ok {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.reset()
+	}
+
+	for _, tc := range cases {
+		value := NewNumber(reporter, tc.value)
+
+		check(value.IsPositive(), tc.positive)
+		check(value.IsNegative(), tc.negative)
+		check(value.IsZero(), tc.zero)
+		check(value.IsNonNegative(), tc.nonNegative)
+	}
+}
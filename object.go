@@ -1,7 +1,12 @@
 package httpexpect
 
 import (
+	"fmt"
+	"net/textproto"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // Object provides methods to inspect attached map[string]interface{} object
@@ -44,12 +49,122 @@ func (o *Object) Path(path string) *Value {
 	return getPath(&o.chain, o.value, path)
 }
 
+// JSONPath is similar to Value.JSONPath.
+func (o *Object) JSONPath(expr string) *Value {
+	return getJSONPath(&o.chain, o.value, expr)
+}
+
+// PathObject is similar to Path, but returns Object instead of Value.
+//
+// If path doesn't exist or its value is not an object, failure is reported
+// and empty (but non-nil) value is returned.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{
+//      "foo": map[string]interface{}{"bar": 123},
+//  })
+//  object.PathObject("$.foo").ValueEqual("bar", 123)
+func (o *Object) PathObject(path string) *Object {
+	value := getPath(&o.chain, o.value, path)
+	if value.chain.failed() {
+		return &Object{value.chain.clone(), nil}
+	}
+	data, ok := value.value.(map[string]interface{})
+	if !ok {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected object at path '%s'", path),
+			actual:     dumpValue(value.value),
+		})
+		return &Object{o.chain.clone(), nil}
+	}
+	return &Object{o.chain.clone(), data}
+}
+
+// PathArray is similar to Path, but returns Array instead of Value.
+//
+// If path doesn't exist or its value is not an array, failure is reported
+// and empty (but non-nil) value is returned.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{
+//      "foo": map[string]interface{}{"bar": []interface{}{1, 2}},
+//  })
+//  object.PathArray("$.foo.bar").Elements(1, 2)
+func (o *Object) PathArray(path string) *Array {
+	value := getPath(&o.chain, o.value, path)
+	if value.chain.failed() {
+		return &Array{value.chain.clone(), nil}
+	}
+	data, ok := value.value.([]interface{})
+	if !ok {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected array at path '%s'", path),
+			actual:     dumpValue(value.value),
+		})
+		return &Array{o.chain.clone(), nil}
+	}
+	return &Array{o.chain.clone(), data}
+}
+
 // Schema is similar to Value.Schema.
 func (o *Object) Schema(schema interface{}) *Object {
-	checkSchema(&o.chain, o.value, schema)
+	checkSchema(&o.chain, "object", o.value, schema)
+	return o
+}
+
+// Decode unmarshals the underlying object into target.
+//
+// target should be a non-nil pointer to struct or map. The object is
+// marshaled to JSON and then unmarshaled into target using encoding/json,
+// so json struct tags are honored. If target has wrong type or if the
+// object can't be unmarshaled into it, failure is reported.
+//
+// Like with json.Unmarshal, object keys that don't match any struct field
+// are ignored, and struct fields without matching keys are left unchanged.
+// Use KeysEqual or ContainsKey to check the set of keys explicitly.
+//
+// Example:
+//  type User struct {
+//      Name string `json:"name"`
+//      Age  int    `json:"age"`
+//  }
+//
+//  var user User
+//  object := NewObject(t, map[string]interface{}{"name": "john", "age": 42})
+//  object.Decode(&user)
+//
+//  assert.Equal(t, User{Name: "john", Age: 42}, user)
+func (o *Object) Decode(target interface{}) *Object {
+	if o.chain.failed() {
+		return o
+	}
+
+	if rv := reflect.ValueOf(target); target != nil &&
+		rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		if k := rv.Elem().Kind(); k != reflect.Struct && k != reflect.Map {
+			o.chain.fail(
+				"\nexpected pointer to struct or map passed to Decode, but got:\n %T",
+				target)
+			return o
+		}
+	}
+
+	canonDecode(&o.chain, o.value, target)
 	return o
 }
 
+// Length returns a new Number object that may be used to inspect
+// number of keys in object.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": 123, "bar": 456})
+//  object.Length().Equal(2)
+func (o *Object) Length() *Number {
+	return &Number{o.chain.clone(), float64(len(o.value)), nil}
+}
+
 // Keys returns a new Array object that may be used to inspect objects keys.
 //
 // Example:
@@ -60,7 +175,26 @@ func (o *Object) Keys() *Array {
 	for k := range o.value {
 		keys = append(keys, k)
 	}
-	return &Array{o.chain, keys}
+	return &Array{o.chain.clone(), keys}
+}
+
+// KeysSorted is like Keys, but returned keys are sorted in lexicographical
+// order, so that they can be compared with an ordered list.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": 123, "bar": 456})
+//  object.KeysSorted().Equal([]interface{}{"bar", "foo"})
+func (o *Object) KeysSorted() *Array {
+	keys := make([]string, 0, len(o.value))
+	for k := range o.value {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := []interface{}{}
+	for _, k := range keys {
+		values = append(values, k)
+	}
+	return &Array{o.chain.clone(), values}
 }
 
 // Values returns a new Array object that may be used to inspect objects values.
@@ -73,7 +207,7 @@ func (o *Object) Values() *Array {
 	for _, v := range o.value {
 		values = append(values, v)
 	}
-	return &Array{o.chain, values}
+	return &Array{o.chain.clone(), values}
 }
 
 // Value returns a new Value object that may be used to inspect single value
@@ -83,13 +217,114 @@ func (o *Object) Values() *Array {
 //  object := NewObject(t, map[string]interface{}{"foo": 123})
 //  object.Value("foo").Number().Equal(123)
 func (o *Object) Value(key string) *Value {
+	key = o.canonKey(key)
 	value, ok := o.value[key]
 	if !ok {
-		o.chain.fail("\nexpected object containing key '%s', but got:\n%s",
-			key, dumpValue(o.value))
-		return &Value{o.chain, nil}
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected object containing key '%s'", key),
+			actual:     dumpValue(o.value),
+		})
+		return &Value{o.chain.clone(), nil}
+	}
+	return &Value{o.chain.child(keyPath(key)), value}
+}
+
+// Subset returns a new Object containing only given keys of this object.
+//
+// If some of the keys are missing, failure is reported, listing all missing
+// keys, and empty (but non-nil) object is returned.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{
+//      "1": "foo", "2": "bar", "3": "baz",
+//  })
+//  object.Subset("1", "3").Equal(map[string]interface{}{"1": "foo", "3": "baz"})
+func (o *Object) Subset(keys ...string) *Object {
+	if o.chain.failed() {
+		return &Object{o.chain.clone(), nil}
+	}
+	subset := map[string]interface{}{}
+	missing := []string{}
+	for _, k := range keys {
+		key := o.canonKey(k)
+		if value, ok := o.value[key]; ok {
+			subset[key] = value
+		} else {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) != 0 {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  "expected object containing keys",
+			expected:   dumpValue(keys),
+			actual:     dumpValue(o.value),
+			details:    "\n\nmissing keys:\n" + dumpValue(missing),
+		})
+		return &Object{o.chain.clone(), nil}
+	}
+	return &Object{o.chain.clone(), subset}
+}
+
+// Clone returns a new Object attached to the same value, with a fresh
+// chain that is not failed, even if this object is.
+//
+// Once an assertion fails, all subsequent assertions on the same object
+// and objects derived from it are skipped. Clone is useful when a test
+// checks many unrelated properties of a single object: checking every
+// group of properties on its own clone ensures that failure in one group
+// doesn't hide failures in others. Clone keeps reporter and all other
+// settings, like comparator set by WithComparator.
+//
+// The value is not copied, so it should not be modified via Raw.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": 123, "bar": "baz"})
+//
+//  object.Clone().ValueEqual("foo", 456)    // failure
+//  object.Clone().ValueEqual("bar", "baz")  // still checked
+func (o *Object) Clone() *Object {
+	chain := o.chain.clone()
+	chain.reset()
+	return &Object{chain, o.value}
+}
+
+// MergeWith returns a new Object with deep merge of this object and given
+// Go map or struct. Original object is not modified. Before merging, value
+// is converted to canonical form.
+//
+// value should be map[string]interface{} or struct.
+//
+// If both object and value have given key, and both values for the key are
+// objects, they are merged recursively. Otherwise, value from the argument
+// wins; in particular, arrays are replaced, not merged.
+//
+// This is not an assertion, but a helper to build expected values, e.g.
+// from a base fixture overridden per test case.
+//
+// Example:
+//  base := NewObject(t, map[string]interface{}{
+//      "name": "foo",
+//      "meta": map[string]interface{}{"a": 1, "b": 2},
+//  })
+//  expected := base.MergeWith(map[string]interface{}{
+//      "meta": map[string]interface{}{"b": 3},
+//  })
+//  expected.Equal(map[string]interface{}{
+//      "name": "foo",
+//      "meta": map[string]interface{}{"a": 1, "b": 3},
+//  })
+func (o *Object) MergeWith(value interface{}) *Object {
+	if o.chain.failed() {
+		return &Object{o.chain.clone(), nil}
+	}
+	other, ok := canonMap(&o.chain, value)
+	if !ok {
+		return &Object{o.chain.clone(), nil}
 	}
-	return &Value{o.chain, value}
+	other = o.canonKeys(other)
+	return &Object{o.chain.clone(), mergeMaps(o.value, other)}
 }
 
 // Empty succeeds if object is empty.
@@ -110,6 +345,37 @@ func (o *Object) NotEmpty() *Object {
 	return o.NotEqual(map[string]interface{}{})
 }
 
+// WithComparator sets function used to compare values instead of
+// default comparison, and returns object itself.
+//
+// The comparator is used by Equal, NotEqual, ValueEqual, ValueNotEqual,
+// ValueNotEqualOrMissing, ContainsMap, ValueContains, and similar methods,
+// and is inherited by all objects derived from this object afterwards, e.g.
+// Value(key).Equal() and Value(key).Array().Equal() use it as well. It is
+// given expected and actual values, both converted to canonical form, except
+// that time.Time values in expected value are kept as is. Equal and
+// ValueEqual pass whole compared values to it, while ContainsMap and
+// ValueContains pass individual leaf values. When Equal fails, comparator
+// is also called for values of every key present in both objects, to find
+// keys with different values. If cmp is nil, default comparison is used
+// again.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": "BAR"})
+//  object.WithComparator(func(a, b interface{}) bool {
+//      as, ok1 := a.(string)
+//      bs, ok2 := b.(string)
+//      if ok1 && ok2 {
+//          return strings.EqualFold(as, bs)
+//      }
+//      return reflect.DeepEqual(a, b)
+//  })
+//  object.ValueEqual("foo", "bar")
+func (o *Object) WithComparator(cmp func(a, b interface{}) bool) *Object {
+	o.chain.comparator = cmp
+	return o
+}
+
 // Equal succeeds if object is equal to given Go map or struct.
 // Before comparison, both object and value are converted to canonical form.
 //
@@ -123,11 +389,22 @@ func (o *Object) Equal(value interface{}) *Object {
 	if !ok {
 		return o
 	}
-	if !reflect.DeepEqual(expected, o.value) {
-		o.chain.fail("\nexpected object equal to:\n%s\n\nbut got:\n%s\n\ndiff:\n%s",
-			dumpValue(expected),
-			dumpValue(o.value),
-			diffValues(expected, o.value))
+	markTimes(value, expected)
+	expected = o.canonKeys(expected)
+	if !o.chain.equal(expected, o.value) {
+		keys := diffMaps(expected, o.value, o.chain.equal)
+		summary := ""
+		if keys.count() != 0 {
+			summary = "\n\nsummary:\n" + keys.String()
+		}
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  "expected object equal to",
+			expected:   dumpValue(expected),
+			actual:     dumpValue(o.value),
+			details:    summary + "\n\ndiff:\n" + diffValues(expected, o.value),
+			keys:       keys,
+		})
 	}
 	return o
 }
@@ -145,9 +422,70 @@ func (o *Object) NotEqual(v interface{}) *Object {
 	if !ok {
 		return o
 	}
-	if reflect.DeepEqual(expected, o.value) {
-		o.chain.fail("\nexpected object not equal to:\n%s",
-			dumpValue(expected))
+	markTimes(v, expected)
+	expected = o.canonKeys(expected)
+	if o.chain.equal(expected, o.value) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  "expected object not equal to",
+			expected:   dumpValue(expected),
+		})
+	}
+	return o
+}
+
+// EqualDelta succeeds if object is equal to given Go map or struct, treating
+// numbers as equal if they are within delta of each other. Non-numeric values
+// are compared exactly. Before comparison, both object and value are converted
+// to canonical form.
+//
+// value should be map[string]interface{} or struct.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": 123.1, "bar": "baz"})
+//  object.EqualDelta(map[string]interface{}{"foo": 123, "bar": "baz"}, 0.2)
+func (o *Object) EqualDelta(value interface{}, delta float64) *Object {
+	expected, ok := canonMap(&o.chain, value)
+	if !ok {
+		return o
+	}
+	expected = o.canonKeys(expected)
+	if !equalDelta(expected, o.value, delta) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  "expected object equal to",
+			expected:   dumpValue(expected),
+			actual:     dumpValue(o.value),
+			details: fmt.Sprintf(
+				"\n\ndelta:\n %v\n\ndiff:\n%s", delta, diffValues(expected, o.value)),
+		})
+	}
+	return o
+}
+
+// NotEqualDelta succeeds if object is not equal to given Go map or struct,
+// treating numbers as equal if they are within delta of each other.
+// Non-numeric values are compared exactly. Before comparison, both object
+// and value are converted to canonical form.
+//
+// value should be map[string]interface{} or struct.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": 123.1, "bar": "baz"})
+//  object.NotEqualDelta(map[string]interface{}{"foo": 123, "bar": "baz"}, 0.01)
+func (o *Object) NotEqualDelta(value interface{}, delta float64) *Object {
+	expected, ok := canonMap(&o.chain, value)
+	if !ok {
+		return o
+	}
+	expected = o.canonKeys(expected)
+	if equalDelta(expected, o.value, delta) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  "expected object not equal to",
+			expected:   dumpValue(expected),
+			details:    fmt.Sprintf("\n\ndelta:\n %v", delta),
+		})
 	}
 	return o
 }
@@ -158,9 +496,13 @@ func (o *Object) NotEqual(v interface{}) *Object {
 //  object := NewObject(t, map[string]interface{}{"foo": 123})
 //  object.ContainsKey("foo")
 func (o *Object) ContainsKey(key string) *Object {
+	key = o.canonKey(key)
 	if !o.containsKey(key) {
-		o.chain.fail("\nexpected object containing key '%s', but got:\n%s",
-			key, dumpValue(o.value))
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected object containing key '%s'", key),
+			actual:     dumpValue(o.value),
+		})
 	}
 	return o
 }
@@ -171,10 +513,60 @@ func (o *Object) ContainsKey(key string) *Object {
 //  object := NewObject(t, map[string]interface{}{"foo": 123})
 //  object.NotContainsKey("bar")
 func (o *Object) NotContainsKey(key string) *Object {
+	key = o.canonKey(key)
 	if o.containsKey(key) {
-		o.chain.fail(
-			"\nexpected object not containing key '%s', but got:\n%s", key,
-			dumpValue(o.value))
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected object not containing key '%s'", key),
+			actual:     dumpValue(o.value),
+		})
+	}
+	return o
+}
+
+// KeysEqual succeeds if object contains all given keys and only them.
+// Keys may be given in any order.
+//
+// On failure, both the keys missing from object and the unexpected
+// extra keys are reported.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": 123, "bar": 456})
+//  object.KeysEqual("bar", "foo")  // success
+//  object.KeysEqual("foo")         // failure (extra key "bar")
+//  object.KeysEqual("foo", "baz")  // failure (missing key "baz", extra key "bar")
+func (o *Object) KeysEqual(keys ...string) *Object {
+	expected := map[string]bool{}
+	for _, k := range keys {
+		expected[o.canonKey(k)] = true
+	}
+
+	missing := []string{}
+	for _, k := range keys {
+		if !o.containsKey(o.canonKey(k)) {
+			missing = append(missing, k)
+		}
+	}
+
+	extra := []string{}
+	for k := range o.value {
+		if !expected[k] {
+			extra = append(extra, k)
+		}
+	}
+
+	if len(missing) != 0 || len(extra) != 0 {
+		sort.Strings(missing)
+		sort.Strings(extra)
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  "expected object with keys equal to",
+			expected:   dumpValue(keys),
+			actual:     dumpValue(o.value),
+			details: fmt.Sprintf(
+				"\n\nmissing keys:\n%s\n\nextra keys:\n%s",
+				dumpValue(missing), dumpValue(extra)),
+		})
 	}
 	return o
 }
@@ -211,8 +603,74 @@ func (o *Object) NotContainsKey(key string) *Object {
 //  })
 func (o *Object) ContainsMap(value interface{}) *Object {
 	if !o.containsMap(value) {
-		o.chain.fail("\nexpected object containing sub-object:\n%s\n\nbut got:\n%s",
-			dumpValue(value), dumpValue(o.value))
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  "expected object containing sub-object",
+			expected:   dumpValue(value),
+			actual:     dumpValue(o.value),
+		})
+	}
+	return o
+}
+
+// ContainsOption defines an option for ContainsMapFunc.
+type ContainsOption int
+
+const (
+	// ContainsArraySubset enables subset mode for arrays: a nested array
+	// matches if it contains every expected element, in any order, possibly
+	// among other elements. Array elements are matched recursively, so
+	// objects inside arrays may be partial too.
+	ContainsArraySubset ContainsOption = iota + 1
+)
+
+// ContainsMapFunc is like ContainsMap, but comparison may be tuned using
+// given options.
+//
+// Without options, it behaves exactly like ContainsMap: nested objects may
+// be partial, but nested arrays should match exactly.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{
+//      "foo": 123,
+//      "bar": []interface{}{
+//          map[string]interface{}{"id": 1, "name": "x"},
+//          map[string]interface{}{"id": 2, "name": "y"},
+//      },
+//  })
+//
+//  object.ContainsMapFunc(map[string]interface{}{  // success
+//      "bar": []interface{}{
+//          map[string]interface{}{"id": 2},
+//      },
+//  }, ContainsArraySubset)
+func (o *Object) ContainsMapFunc(value interface{}, opts ...ContainsOption) *Object {
+	if o.chain.failed() {
+		return o
+	}
+	subset := false
+	for _, opt := range opts {
+		switch opt {
+		case ContainsArraySubset:
+			subset = true
+		default:
+			o.chain.fail("\nunexpected option passed to ContainsMapFunc:\n %d", opt)
+			return o
+		}
+	}
+	submap, ok := canonMap(&o.chain, value)
+	if !ok {
+		return o
+	}
+	markTimes(value, submap)
+	submap = o.canonKeys(submap)
+	if !o.chain.containsValue(o.value, submap, subset) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  "expected object containing sub-object",
+			expected:   dumpValue(submap),
+			actual:     dumpValue(o.value),
+		})
 	}
 	return o
 }
@@ -227,8 +685,53 @@ func (o *Object) ContainsMap(value interface{}) *Object {
 //  object.NotContainsMap(map[string]interface{}{"foo": 123, "bar": "no-no-no"})
 func (o *Object) NotContainsMap(value interface{}) *Object {
 	if o.containsMap(value) {
-		o.chain.fail("\nexpected object not containing sub-object:\n%s\n\nbut got:\n%s",
-			dumpValue(value), dumpValue(o.value))
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  "expected object not containing sub-object",
+			expected:   dumpValue(value),
+			actual:     dumpValue(o.value),
+		})
+	}
+	return o
+}
+
+// ValueType succeeds if object contains given key and JSON type of its value
+// is equal to given kind.
+//
+// kind should be one of "null", "boolean", "number", "string", "array", or
+// "object". If object doesn't contain given key, failure is reported.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": 123, "bar": "str"})
+//  object.ValueType("foo", "number")
+//  object.ValueType("bar", "string")
+func (o *Object) ValueType(key string, kind string) *Object {
+	key = o.canonKey(key)
+	if o.chain.failed() {
+		return o
+	}
+	switch kind {
+	case "null", "boolean", "number", "string", "array", "object":
+	default:
+		o.chain.fail("\nunexpected kind passed to ValueType:\n %q\n\n"+
+			"expected one of: null, boolean, number, string, array, object", kind)
+		return o
+	}
+	if !o.containsKey(key) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected object containing key '%s'", key),
+			actual:     dumpValue(o.value),
+		})
+		return o
+	}
+	if actual := jsonType(o.value[key]); actual != kind {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected value for key '%s' to be %s", key, kind),
+			got:        fmt.Sprintf("but got %s", actual),
+			actual:     dumpValue(o.value[key]),
+		})
 	}
 	return o
 }
@@ -238,26 +741,86 @@ func (o *Object) NotContainsMap(value interface{}) *Object {
 //
 // value should be map[string]interface{} or struct.
 //
+// If value is time.Time and object's value is a string, the string is parsed
+// as a timestamp and compared with value as a time instant, so that
+// timestamps in different timezones are considered equal if they denote the
+// same instant. RFC 3339 layout is used by default; see Config.TimeLayout.
+//
 // Example:
 //  object := NewObject(t, map[string]interface{}{"foo": 123})
 //  object.ValueEqual("foo", 123)
+//
+//  object := NewObject(t, map[string]interface{}{
+//      "time": "2020-01-02T05:04:05+02:00",
+//  })
+//  object.ValueEqual("time", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
 func (o *Object) ValueEqual(key string, value interface{}) *Object {
+	key = o.canonKey(key)
 	if !o.containsKey(key) {
-		o.chain.fail("\nexpected object containing key '%s', but got:\n%s",
-			key, dumpValue(o.value))
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected object containing key '%s'", key),
+			actual:     dumpValue(o.value),
+		})
 		return o
 	}
 	expected, ok := canonValue(&o.chain, value)
 	if !ok {
 		return o
 	}
-	if !reflect.DeepEqual(expected, o.value[key]) {
-		o.chain.fail(
-			"\nexpected value for key '%s' equal to:\n%s\n\nbut got:\n%s\n\ndiff:\n%s",
-			key,
-			dumpValue(expected),
-			dumpValue(o.value[key]),
-			diffValues(expected, o.value[key]))
+	expected = markTimes(value, expected)
+	if !o.valueEqual(key, expected) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected value for key '%s' equal to", key),
+			expected:   dumpValue(expected),
+			actual:     dumpValue(o.value[key]),
+			details:    "\n\ndiff:\n" + diffValues(expected, o.value[key]),
+		})
+	}
+	return o
+}
+
+// ValueEqualNum succeeds if object's value for given key is a number equal
+// to given number.
+//
+// Unlike ValueEqual, it requires value to be a number and compares it
+// numerically, which makes intent explicit when expected value comes from
+// a typed integer, e.g. ValueEqualNum("count", float64(count)).
+//
+// If object doesn't contain any value for given key, or the value is not
+// a number, failure is reported.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"count": 5})
+//  object.ValueEqualNum("count", 5)
+func (o *Object) ValueEqualNum(key string, n float64) *Object {
+	key = o.canonKey(key)
+	if !o.containsKey(key) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected object containing key '%s'", key),
+			actual:     dumpValue(o.value),
+		})
+		return o
+	}
+	actual, ok := numberFloat(o.value[key])
+	if !ok {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected numeric value for key '%s'", key),
+			got:        fmt.Sprintf("but got %s", jsonType(o.value[key])),
+			actual:     dumpValue(o.value[key]),
+		})
+		return o
+	}
+	if actual != n {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected value for key '%s' equal to", key),
+			expected:   fmt.Sprintf(" %v", n),
+			actual:     fmt.Sprintf(" %v", actual),
+		})
 	}
 	return o
 }
@@ -267,29 +830,197 @@ func (o *Object) ValueEqual(key string, value interface{}) *Object {
 //
 // value should be map[string]interface{} or struct.
 //
+// time.Time values are handled in the same way as in ValueEqual.
+//
 // If object doesn't contain any value for given key, failure is reported.
+// Use ValueNotEqualOrMissing if a missing key should be accepted.
 //
 // Example:
 //  object := NewObject(t, map[string]interface{}{"foo": 123})
 //  object.ValueNotEqual("foo", "bad value")  // success
 //  object.ValueNotEqual("bar", "bad value")  // failure! (key is missing)
 func (o *Object) ValueNotEqual(key string, value interface{}) *Object {
+	key = o.canonKey(key)
 	if !o.containsKey(key) {
-		o.chain.fail("\nexpected object containing key '%s', but got:\n%s",
-			key, dumpValue(o.value))
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected object containing key '%s'", key),
+			actual:     dumpValue(o.value),
+		})
 		return o
 	}
 	expected, ok := canonValue(&o.chain, value)
 	if !ok {
 		return o
 	}
-	if reflect.DeepEqual(expected, o.value[key]) {
-		o.chain.fail("\nexpected value for key '%s' not equal to:\n%s",
-			key, dumpValue(expected))
+	expected = markTimes(value, expected)
+	if o.valueEqual(key, expected) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected value for key '%s' not equal to", key),
+			expected:   dumpValue(expected),
+		})
 	}
 	return o
 }
 
+// ValueNotEqualOrMissing succeeds if object doesn't contain given key, or
+// object's value for given key is not equal to given Go value. Before
+// comparison, both values are converted to canonical form.
+//
+// Unlike ValueNotEqual, a missing key is not a failure. This is useful for
+// optional fields that may be omitted, but should not have given value when
+// present.
+//
+// time.Time values are handled in the same way as in ValueEqual.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": 123})
+//  object.ValueNotEqualOrMissing("foo", "bad value")  // success
+//  object.ValueNotEqualOrMissing("bar", "bad value")  // success
+//  object.ValueNotEqualOrMissing("foo", 123)          // failure
+func (o *Object) ValueNotEqualOrMissing(key string, value interface{}) *Object {
+	key = o.canonKey(key)
+	expected, ok := canonValue(&o.chain, value)
+	if !ok {
+		return o
+	}
+	expected = markTimes(value, expected)
+	if !o.containsKey(key) {
+		return o
+	}
+	if o.valueEqual(key, expected) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion: fmt.Sprintf(
+				"expected value for key '%s' missing or not equal to", key),
+			expected: dumpValue(expected),
+		})
+	}
+	return o
+}
+
+// ValueContains succeeds if object's value for given key contains given Go
+// value. Before comparison, both values are converted to canonical form.
+//
+// Containment depends on the type of object's value:
+//  - for string, sub should be a string, and substring check is performed
+//  - for array, sub may be any value, and element check is performed
+//  - for object, sub should be a map or struct, and sub-object check is
+//    performed, like in ContainsMap
+//
+// If object doesn't contain any value for given key, or the value has
+// other type, failure is reported.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{
+//      "str": "Hello, World!",
+//      "arr": []interface{}{"foo", "bar"},
+//      "obj": map[string]interface{}{"a": 1, "b": 2},
+//  })
+//  object.ValueContains("str", "World")
+//  object.ValueContains("arr", "foo")
+//  object.ValueContains("obj", map[string]interface{}{"a": 1})
+func (o *Object) ValueContains(key string, sub interface{}) *Object {
+	key = o.canonKey(key)
+	if o.chain.failed() {
+		return o
+	}
+	if !o.containsKey(key) {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected object containing key '%s'", key),
+			actual:     dumpValue(o.value),
+		})
+		return o
+	}
+	expected, ok := canonValue(&o.chain, sub)
+	if !ok {
+		return o
+	}
+	expected = markTimes(sub, expected)
+
+	actual := o.value[key]
+	contains := false
+
+	switch av := actual.(type) {
+	case string:
+		es, ok := expected.(string)
+		if !ok {
+			o.chain.fail(
+				"\nexpected string passed to ValueContains for key '%s', but got:\n%s",
+				key, dumpValue(expected))
+			return o
+		}
+		contains = strings.Contains(av, es)
+
+	case []interface{}:
+		for _, e := range av {
+			if o.chain.equal(expected, e) {
+				contains = true
+				break
+			}
+		}
+
+	case map[string]interface{}:
+		em, ok := expected.(map[string]interface{})
+		if !ok {
+			o.chain.fail(
+				"\nexpected object passed to ValueContains for key '%s', but got:\n%s",
+				key, dumpValue(expected))
+			return o
+		}
+		contains = o.chain.containsValue(av, em, false)
+
+	default:
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion: fmt.Sprintf(
+				"expected value for key '%s' to be string, array, or object", key),
+			got:    fmt.Sprintf("but got %s", jsonType(actual)),
+			actual: dumpValue(actual),
+		})
+		return o
+	}
+
+	if !contains {
+		o.chain.failWith(failure{
+			assertType: "object",
+			assertion:  fmt.Sprintf("expected value for key '%s' containing", key),
+			expected:   dumpValue(expected),
+			actual:     dumpValue(actual),
+		})
+	}
+	return o
+}
+
+func (o *Object) valueEqual(key string, expected interface{}) bool {
+	return o.chain.equal(expected, o.value[key])
+}
+
+// canonKey returns canonical form of key. Keys of header objects (see
+// Response.Headers) are case-insensitive and are canonicalized as MIME
+// header keys; other keys are returned as is.
+func (o *Object) canonKey(key string) string {
+	if o.chain.headerKeys {
+		return textproto.CanonicalMIMEHeaderKey(key)
+	}
+	return key
+}
+
+// canonKeys returns map with canonical form of top-level keys of given map
+// (see canonKey).
+func (o *Object) canonKeys(m map[string]interface{}) map[string]interface{} {
+	if !o.chain.headerKeys {
+		return m
+	}
+	canon := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		canon[o.canonKey(k)] = v
+	}
+	return canon
+}
+
 func (o *Object) containsKey(key string) bool {
 	for k := range o.value {
 		if k == key {
@@ -304,26 +1035,139 @@ func (o *Object) containsMap(sm interface{}) bool {
 	if !ok {
 		return false
 	}
-	return checkContainsMap(o.value, submap)
+	markTimes(sm, submap)
+	submap = o.canonKeys(submap)
+	return o.chain.containsValue(o.value, submap, false)
+}
+
+// mapDiff describes top-level differences between two maps.
+type mapDiff struct {
+	onlyExpected []string
+	onlyActual   []string
+	different    []string
+}
+
+// diffMaps returns sorted lists of keys present only in expected map, only
+// in actual map, and present in both maps but with different values.
+func diffMaps(
+	expected, actual map[string]interface{}, equal func(a, b interface{}) bool,
+) mapDiff {
+	var d mapDiff
+	for k, ev := range expected {
+		if av, ok := actual[k]; !ok {
+			d.onlyExpected = append(d.onlyExpected, k)
+		} else if !equal(ev, av) {
+			d.different = append(d.different, k)
+		}
+	}
+	for k := range actual {
+		if _, ok := expected[k]; !ok {
+			d.onlyActual = append(d.onlyActual, k)
+		}
+	}
+	sort.Strings(d.onlyExpected)
+	sort.Strings(d.onlyActual)
+	sort.Strings(d.different)
+	return d
+}
+
+func (d mapDiff) count() int {
+	return len(d.onlyExpected) + len(d.onlyActual) + len(d.different)
+}
+
+// String returns e.g.:
+//   3 keys differ
+//   only in expected: "a"
+//   only in actual: "b"
+//   different values: "c"
+func (d mapDiff) String() string {
+	var b strings.Builder
+	if d.count() == 1 {
+		b.WriteString(" 1 key differs")
+	} else {
+		fmt.Fprintf(&b, " %d keys differ", d.count())
+	}
+	sections := []struct {
+		name string
+		keys []string
+	}{
+		{"only in expected", d.onlyExpected},
+		{"only in actual", d.onlyActual},
+		{"different values", d.different},
+	}
+	for _, s := range sections {
+		if len(s.keys) == 0 {
+			continue
+		}
+		quoted := make([]string, 0, len(s.keys))
+		for _, k := range s.keys {
+			quoted = append(quoted, strconv.Quote(k))
+		}
+		fmt.Fprintf(&b, "\n %s: %s", s.name, strings.Join(quoted, ", "))
+	}
+	return b.String()
 }
 
-func checkContainsMap(outer, inner map[string]interface{}) bool {
-	for k, iv := range inner {
-		ov, ok := outer[k]
+// containsValue checks whether outer contains inner: maps are checked
+// recursively for subset of keys, arrays (if subset is true) for subset of
+// elements, and other values are compared using chain.equal.
+func (c *chain) containsValue(outer, inner interface{}, subset bool) bool {
+	switch iv := inner.(type) {
+	case map[string]interface{}:
+		ov, ok := outer.(map[string]interface{})
 		if !ok {
 			return false
 		}
-		if ovm, ok := ov.(map[string]interface{}); ok {
-			if ivm, ok := iv.(map[string]interface{}); ok {
-				if !checkContainsMap(ovm, ivm) {
-					return false
-				}
-				continue
+		for k, ie := range iv {
+			oe, ok := ov[k]
+			if !ok {
+				return false
+			}
+			if !c.containsValue(oe, ie, subset) {
+				return false
 			}
 		}
-		if !reflect.DeepEqual(ov, iv) {
+		return true
+
+	case []interface{}:
+		if !subset {
+			break
+		}
+		ov, ok := outer.([]interface{})
+		if !ok {
 			return false
 		}
+		for _, ie := range iv {
+			found := false
+			for _, oe := range ov {
+				if c.containsValue(oe, ie, subset) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	return c.equal(inner, outer)
+}
+
+func mergeMaps(base, override map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		result[k] = v
+	}
+	for k, v := range override {
+		bm, ok1 := result[k].(map[string]interface{})
+		om, ok2 := v.(map[string]interface{})
+		if ok1 && ok2 {
+			result[k] = mergeMaps(bm, om)
+		} else {
+			result[k] = v
+		}
 	}
-	return true
+	return result
 }
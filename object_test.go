@@ -1,7 +1,11 @@
 package httpexpect
 
 import (
+	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -16,13 +20,25 @@ func TestObjectFailed(t *testing.T) {
 	value.chain.assertFailed(t)
 
 	value.Path("$").chain.assertFailed(t)
+	value.JSONPath("$").chain.assertFailed(t)
+	value.PathObject("$").chain.assertFailed(t)
+	value.PathArray("$").chain.assertFailed(t)
+	value.Subset("foo").chain.assertFailed(t)
+	value.MergeWith(map[string]interface{}{}).chain.assertFailed(t)
+	value.Clone().chain.assertOK(t)
 	value.Schema("")
+	value.Decode(&struct{}{})
+	value.WithComparator(nil).chain.assertFailed(t)
 
+	assert.False(t, value.Length() == nil)
 	assert.False(t, value.Keys() == nil)
+	assert.False(t, value.KeysSorted() == nil)
 	assert.False(t, value.Values() == nil)
 	assert.False(t, value.Value("foo") == nil)
 
+	value.Length().chain.assertFailed(t)
 	value.Keys().chain.assertFailed(t)
+	value.KeysSorted().chain.assertFailed(t)
 	value.Values().chain.assertFailed(t)
 	value.Value("foo").chain.assertFailed(t)
 
@@ -30,12 +46,20 @@ func TestObjectFailed(t *testing.T) {
 	value.NotEmpty()
 	value.Equal(nil)
 	value.NotEqual(nil)
+	value.EqualDelta(nil, 0)
+	value.NotEqualDelta(nil, 0)
 	value.ContainsKey("foo")
 	value.NotContainsKey("foo")
+	value.KeysEqual("foo")
 	value.ContainsMap(nil)
+	value.ContainsMapFunc(nil)
 	value.NotContainsMap(nil)
+	value.ValueType("foo", "null")
+	value.ValueContains("foo", nil)
 	value.ValueEqual("foo", nil)
+	value.ValueEqualNum("foo", 0)
 	value.ValueNotEqual("foo", nil)
+	value.ValueNotEqualOrMissing("foo", nil)
 }
 
 func TestObjectGetters(t *testing.T) {
@@ -102,6 +126,50 @@ func TestObjectGetters(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestObjectLength(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value1 := NewObject(reporter, map[string]interface{}{})
+	value1.Length().Equal(0).chain.assertOK(t)
+	value1.chain.assertOK(t)
+
+	value2 := NewObject(reporter, map[string]interface{}{
+		"foo": 1,
+		"bar": map[string]interface{}{"a": 1, "b": 2},
+	})
+	value2.Length().Equal(2).chain.assertOK(t)
+	value2.Length().Equal(4).chain.assertFailed(t)
+	value2.chain.assertOK(t)
+}
+
+func TestObjectKeysSorted(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"foo": 1,
+		"bar": 2,
+		"Baz": 3,
+		"baz": 4,
+		"10":  5,
+		"2":   6,
+	})
+
+	keys := value.KeysSorted()
+	value.chain.assertOK(t)
+
+	keys.Equal([]interface{}{"10", "2", "Baz", "bar", "baz", "foo"})
+	keys.chain.assertOK(t)
+	keys.chain.reset()
+
+	keys.Equal([]interface{}{"foo", "baz", "bar", "Baz", "2", "10"})
+	keys.chain.assertFailed(t)
+	keys.chain.reset()
+
+	empty := NewObject(reporter, map[string]interface{}{})
+
+	empty.KeysSorted().Empty().chain.assertOK(t)
+}
+
 func TestObjectEmpty(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -249,6 +317,352 @@ func TestObjectEqualStruct(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestObjectDecode(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	type User struct {
+		Name    string            `json:"name"`
+		Age     int               `json:"age"`
+		Labels  map[string]string `json:"labels"`
+		Ignored string            `json:"-"`
+	}
+
+	value := NewObject(reporter, map[string]interface{}{
+		"name":    "john",
+		"age":     42,
+		"labels":  map[string]interface{}{"a": "b"},
+		"Ignored": "x",
+	})
+
+	var user User
+	value.Decode(&user)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	assert.Equal(t, User{Name: "john", Age: 42, Labels: map[string]string{"a": "b"}},
+		user)
+
+	var m map[string]interface{}
+	value.Decode(&m)
+	value.chain.assertOK(t)
+	value.chain.reset()
+	assert.Equal(t, value.Raw(), m)
+
+	value.Decode(nil)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.Decode(user)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	var nilUser *User
+	value.Decode(nilUser)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	var s []interface{}
+	value.Decode(&s)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	var str string
+	value.Decode(&str)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	var wrong struct {
+		Age string `json:"age"`
+	}
+	value.Decode(&wrong)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestObjectDecodeUnknownFields(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	type User struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+
+	value := NewObject(reporter, map[string]interface{}{
+		"name":    "john",
+		"unknown": 123,
+	})
+
+	user := User{Email: "old@example.com"}
+	value.Decode(&user)
+	value.chain.assertOK(t)
+
+	assert.Equal(t, User{Name: "john", Email: "old@example.com"}, user)
+}
+
+func TestObjectWithComparator(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	foldEqual := func(a, b interface{}) bool {
+		as, ok1 := a.(string)
+		bs, ok2 := b.(string)
+		if ok1 && ok2 {
+			return strings.EqualFold(as, bs)
+		}
+		return reflect.DeepEqual(a, b)
+	}
+
+	object := NewObject(reporter, map[string]interface{}{
+		"foo": "BAR",
+		"baz": []interface{}{"QUX"},
+	})
+
+	object.ValueEqual("foo", "bar")
+	object.chain.assertFailed(t)
+	object.chain.reset()
+
+	value := object.Value("foo")
+
+	assert.True(t, object.WithComparator(foldEqual) == object)
+
+	object.ValueEqual("foo", "bar")
+	object.chain.assertOK(t)
+	object.chain.reset()
+
+	object.ValueNotEqual("foo", "bar")
+	object.chain.assertFailed(t)
+	object.chain.reset()
+
+	object.ValueNotEqualOrMissing("foo", "bar")
+	object.chain.assertFailed(t)
+	object.chain.reset()
+
+	object.ValueEqual("foo", "baz")
+	object.chain.assertFailed(t)
+	object.chain.reset()
+
+	object.Value("foo").Equal("bar").chain.assertOK(t)
+	object.Value("foo").NotEqual("bar").chain.assertFailed(t)
+
+	object.Value("foo").String().Equal("bar").chain.assertFailed(t)
+
+	object.Value("baz").Array().Equal([]interface{}{"qux"}).chain.assertFailed(t)
+	object.Value("baz").Array().Equal([]interface{}{"QUX"}).chain.assertOK(t)
+
+	object.Equal(map[string]interface{}{
+		"foo": "bar",
+		"baz": []interface{}{"QUX"},
+	})
+	object.chain.assertFailed(t)
+	assert.NotContains(t, reporter.message, "summary:")
+	object.chain.reset()
+
+	object.Equal(map[string]interface{}{
+		"foo": "bar",
+		"baz": []interface{}{"qux"},
+	})
+	object.chain.assertFailed(t)
+	assert.Contains(t, reporter.message,
+		"\nsummary:\n 1 key differs\n different values: \"baz\"\n")
+	object.chain.reset()
+
+	object.NotEqual(map[string]interface{}{
+		"foo": "bar",
+		"baz": []interface{}{"QUX"},
+	})
+	object.chain.assertOK(t)
+	object.chain.reset()
+
+	object.Equal(map[string]interface{}{
+		"foo": "BAR",
+		"baz": []interface{}{"QUX"},
+	})
+	object.chain.assertOK(t)
+	object.chain.reset()
+
+	value.Equal("bar").chain.assertFailed(t)
+
+	object.WithComparator(nil)
+
+	object.ValueEqual("foo", "bar")
+	object.chain.assertFailed(t)
+	object.chain.reset()
+}
+
+func TestObjectEqualSummary(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"a": 1,
+		"b": "foo",
+		"c": []interface{}{1, 2},
+		"d": true,
+	})
+
+	value.Equal(map[string]interface{}{
+		"a": 1,
+		"b": "bar",
+		"c": []interface{}{1, 3},
+		"e": false,
+	})
+	value.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "\nsummary:\n 4 keys differ\n"+
+		" only in expected: \"e\"\n"+
+		" only in actual: \"d\"\n"+
+		" different values: \"b\", \"c\"\n")
+	value.chain.reset()
+
+	value.Equal(map[string]interface{}{
+		"a": 1,
+		"b": "foo",
+		"c": []interface{}{1, 2},
+	})
+	value.chain.assertFailed(t)
+	assert.Contains(t, reporter.message,
+		"\nsummary:\n 1 key differs\n only in actual: \"d\"\n")
+	value.chain.reset()
+}
+
+func TestObjectEqualFailureKeys(t *testing.T) {
+	reporter := NewCollectingReporter()
+
+	NewObject(reporter, map[string]interface{}{
+		"a": 1,
+		"b": "foo",
+		"c": []interface{}{1, 2},
+		"d": true,
+	}).Equal(map[string]interface{}{
+		"a": 1,
+		"b": "bar",
+		"c": []interface{}{1, 3},
+		"e": false,
+	})
+
+	NewObject(reporter, map[string]interface{}{
+		"a": 1,
+	}).NotEqual(map[string]interface{}{
+		"a": 1,
+	})
+
+	failures := reporter.Failures()
+	if !assert.Equal(t, 2, len(failures)) {
+		return
+	}
+
+	assert.Equal(t, []string{"e"}, failures[0].OnlyExpected)
+	assert.Equal(t, []string{"d"}, failures[0].OnlyActual)
+	assert.Equal(t, []string{"b", "c"}, failures[0].Different)
+
+	assert.Nil(t, failures[1].OnlyExpected)
+	assert.Nil(t, failures[1].OnlyActual)
+	assert.Nil(t, failures[1].Different)
+}
+
+func TestDiffMaps(t *testing.T) {
+	d := diffMaps(
+		map[string]interface{}{"a": 1.0, "b": 2.0, "c": 3.0},
+		map[string]interface{}{"a": 1.0, "c": 4.0, "d": 5.0},
+		reflect.DeepEqual,
+	)
+	assert.Equal(t, []string{"b"}, d.onlyExpected)
+	assert.Equal(t, []string{"d"}, d.onlyActual)
+	assert.Equal(t, []string{"c"}, d.different)
+	assert.Equal(t, 3, d.count())
+
+	d = diffMaps(map[string]interface{}{}, map[string]interface{}{},
+		reflect.DeepEqual)
+	assert.Equal(t, 0, d.count())
+
+	d = diffMaps(
+		map[string]interface{}{"a": 1.0, "b": 2.0},
+		map[string]interface{}{"a": 1.5, "b": 4.0},
+		func(a, b interface{}) bool {
+			return math.Abs(a.(float64)-b.(float64)) < 1
+		},
+	)
+	assert.Equal(t, []string{"b"}, d.different)
+	assert.Equal(t, 1, d.count())
+}
+
+func TestObjectEqualDelta(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"foo": 123.1,
+		"bar": []interface{}{1.05, "x", map[string]interface{}{"baz": -0.5}},
+		"qux": "str",
+		"nil": nil,
+	})
+
+	exact := map[string]interface{}{
+		"foo": 123.1,
+		"bar": []interface{}{1.05, "x", map[string]interface{}{"baz": -0.5}},
+		"qux": "str",
+		"nil": nil,
+	}
+
+	close := map[string]interface{}{
+		"foo": 123,
+		"bar": []interface{}{1, "x", map[string]interface{}{"baz": -0.45}},
+		"qux": "str",
+		"nil": nil,
+	}
+
+	value.EqualDelta(exact, 0)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EqualDelta(close, 0.2)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EqualDelta(close, 0.01)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.NotEqualDelta(close, 0.01)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.NotEqualDelta(close, 0.2)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.EqualDelta(map[string]interface{}{
+		"foo": 123,
+		"bar": []interface{}{1, "y", map[string]interface{}{"baz": -0.45}},
+		"qux": "str",
+		"nil": nil,
+	}, 0.2)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.EqualDelta(map[string]interface{}{
+		"foo": 123,
+		"bar": []interface{}{1, "x"},
+		"qux": "str",
+		"nil": nil,
+	}, 0.2)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.EqualDelta(map[string]interface{}{
+		"foo": 123,
+		"bar": []interface{}{1, "x", map[string]interface{}{"baz": -0.45}},
+		"qux": "str",
+	}, 0.2)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.EqualDelta(map[string]interface{}{
+		"foo": "123",
+		"bar": []interface{}{1, "x", map[string]interface{}{"baz": -0.45}},
+		"qux": "str",
+		"nil": nil,
+	}, 0.2)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
 func TestObjectContainsKey(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -279,6 +693,42 @@ func TestObjectContainsKey(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestObjectKeysEqual(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{"foo": 123, "bar": 456})
+
+	value.KeysEqual("foo", "bar")
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.KeysEqual("bar", "foo")
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.KeysEqual("foo")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.KeysEqual("foo", "bar", "baz")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.KeysEqual("foo", "baz")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.KeysEqual()
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	empty := NewObject(reporter, map[string]interface{}{})
+
+	empty.KeysEqual()
+	empty.chain.assertOK(t)
+	empty.chain.reset()
+}
+
 func TestObjectContainsMapSuccess(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -390,6 +840,74 @@ func TestObjectContainsMapFailed(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestObjectContainsMapFunc(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"foo": 123,
+		"bar": []interface{}{"x", "y", "z"},
+		"baz": []interface{}{
+			map[string]interface{}{"id": 1, "name": "a"},
+			map[string]interface{}{"id": 2, "name": "b",
+				"tags": []interface{}{"t1", "t2"}},
+		},
+	})
+
+	cases := []struct {
+		submap interface{}
+		exact  bool
+		subset bool
+	}{
+		{map[string]interface{}{"foo": 123}, true, true},
+		{map[string]interface{}{"bar": []interface{}{"x", "y", "z"}}, true, true},
+		{map[string]interface{}{"bar": []interface{}{"z", "x"}}, false, true},
+		{map[string]interface{}{"bar": []interface{}{}}, false, true},
+		{map[string]interface{}{"bar": []interface{}{"x", "w"}}, false, false},
+		{map[string]interface{}{"bar": "x"}, false, false},
+		{map[string]interface{}{"baz": []interface{}{
+			map[string]interface{}{"id": 2},
+		}}, false, true},
+		{map[string]interface{}{"baz": []interface{}{
+			map[string]interface{}{"tags": []interface{}{"t2"}},
+			map[string]interface{}{"name": "a"},
+		}}, false, true},
+		{map[string]interface{}{"baz": []interface{}{
+			map[string]interface{}{"id": 3},
+		}}, false, false},
+		{map[string]interface{}{"qux": []interface{}{}}, false, false},
+	}
+
+	for _, tc := range cases {
+		value.ContainsMapFunc(tc.submap)
+		if tc.exact {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.reset()
+
+		value.ContainsMap(tc.submap)
+		if tc.exact {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.reset()
+
+		value.ContainsMapFunc(tc.submap, ContainsArraySubset)
+		if tc.subset {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.reset()
+	}
+
+	value.ContainsMapFunc(map[string]interface{}{"foo": 123}, ContainsOption(100))
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
 func TestObjectContainsMapStruct(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -445,6 +963,105 @@ func TestObjectContainsMapStruct(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestObjectValueType(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"null":    nil,
+		"boolean": true,
+		"number":  123,
+		"string":  "str",
+		"array":   []interface{}{1},
+		"object":  map[string]interface{}{"a": 1},
+	})
+
+	kinds := []string{"null", "boolean", "number", "string", "array", "object"}
+
+	for _, key := range kinds {
+		for _, kind := range kinds {
+			value.ValueType(key, kind)
+			if key == kind {
+				value.chain.assertOK(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+			value.chain.reset()
+		}
+	}
+
+	value.ValueType("number", "string")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message, "'number' to be string, but got number")
+
+	value.ValueType("missing", "string")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message, "containing key 'missing'")
+
+	value.ValueType("number", "integer")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestObjectValueContains(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"str": "Hello, World!",
+		"arr": []interface{}{"foo", 123, map[string]interface{}{"x": 1}},
+		"obj": map[string]interface{}{
+			"a": 1,
+			"b": map[string]interface{}{"c": 2, "d": 3},
+		},
+		"num": 123,
+		"nil": nil,
+	})
+
+	cases := []struct {
+		key      string
+		sub      interface{}
+		contains bool
+	}{
+		{"str", "World", true},
+		{"str", "", true},
+		{"str", "world", false},
+		{"str", 123, false},
+		{"arr", "foo", true},
+		{"arr", 123.0, true},
+		{"arr", map[string]interface{}{"x": 1}, true},
+		{"arr", "bar", false},
+		{"arr", map[string]interface{}{}, false},
+		{"obj", map[string]interface{}{"a": 1}, true},
+		{"obj", map[string]interface{}{"b": map[string]interface{}{"c": 2}}, true},
+		{"obj", map[string]interface{}{}, true},
+		{"obj", map[string]interface{}{"a": 2}, false},
+		{"obj", map[string]interface{}{"z": 1}, false},
+		{"obj", "a", false},
+		{"num", 123, false},
+		{"nil", nil, false},
+		{"missing", "foo", false},
+	}
+
+	for _, tc := range cases {
+		value.ValueContains(tc.key, tc.sub)
+		if tc.contains {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.reset()
+	}
+
+	value.ValueContains("str", "world")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	assert.Contains(t, reporter.message, "for key 'str' containing")
+}
+
 func TestObjectValueEqual(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -497,6 +1114,476 @@ func TestObjectValueEqual(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestObjectTypedMaps(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"foo": 123,
+		"bar": map[string]interface{}{
+			"baz": []interface{}{"a", "b"},
+		},
+	})
+
+	value.Equal(map[string]interface{}{
+		"foo": 123,
+		"bar": map[string][]string{"baz": {"a", "b"}},
+	})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsMap(map[string]int{"foo": 123})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsMap(map[string]int{"foo": 456})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ContainsMap(map[string]map[string][]string{
+		"bar": {"baz": {"a", "b"}},
+	})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueEqual("bar", map[string][]string{"baz": {"a", "b"}})
+	value.chain.assertOK(t)
+	value.chain.reset()
+}
+
+func TestObjectSubset(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"1": "foo",
+		"2": map[string]interface{}{"a": "b"},
+		"3": 123,
+	})
+
+	subset := value.Subset("1", "2")
+	subset.chain.assertOK(t)
+	value.chain.assertOK(t)
+	assert.Equal(t, map[string]interface{}{
+		"1": "foo",
+		"2": map[string]interface{}{"a": "b"},
+	}, subset.Raw())
+
+	subset.Equal(map[string]interface{}{
+		"1": "foo",
+		"2": map[string]interface{}{"a": "b"},
+	})
+	subset.chain.assertOK(t)
+
+	empty := value.Subset()
+	empty.chain.assertOK(t)
+	assert.Equal(t, map[string]interface{}{}, empty.Raw())
+
+	bad := value.Subset("1", "4", "5")
+	bad.chain.assertFailed(t)
+	value.chain.assertFailed(t)
+	assert.Nil(t, bad.Raw())
+	assert.Contains(t, reporter.message, `"4"`)
+	assert.Contains(t, reporter.message, `"5"`)
+	value.chain.reset()
+}
+
+func TestObjectClone(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"foo": 123,
+		"bar": "baz",
+	})
+
+	clone1 := value.Clone()
+	clone1.ValueEqual("foo", 456)
+	clone1.chain.assertFailed(t)
+	value.chain.assertOK(t)
+
+	clone1.ValueEqual("bar", "qux")
+	assert.NotContains(t, reporter.message, "qux")
+
+	clone2 := clone1.Clone()
+	clone2.chain.assertOK(t)
+	clone2.ValueEqual("bar", "qux")
+	clone2.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "qux")
+
+	assert.Equal(t, value.Raw(), clone2.Raw())
+
+	compared := false
+	value.WithComparator(func(a, b interface{}) bool {
+		compared = true
+		return true
+	})
+
+	clone3 := value.Clone()
+	clone3.ValueEqual("foo", 456)
+	clone3.chain.assertOK(t)
+	assert.True(t, compared)
+
+	headers := NewObject(reporter, map[string]interface{}{"Content-Type": "foo"})
+	headers.chain.headerKeys = true
+	headers.Clone().ContainsKey("content-type").chain.assertOK(t)
+}
+
+func TestObjectMergeWith(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"name": "foo",
+		"tags": []interface{}{"a", "b"},
+		"meta": map[string]interface{}{
+			"a": 1,
+			"b": map[string]interface{}{"c": 2, "d": 3},
+		},
+	})
+
+	merged := value.MergeWith(map[string]interface{}{
+		"tags": []interface{}{"c"},
+		"meta": map[string]interface{}{
+			"b": map[string]interface{}{"d": 4},
+			"e": 5,
+		},
+		"extra": true,
+	})
+	merged.chain.assertOK(t)
+	value.chain.assertOK(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"name": "foo",
+		"tags": []interface{}{"c"},
+		"meta": map[string]interface{}{
+			"a": 1.0,
+			"b": map[string]interface{}{"c": 2.0, "d": 4.0},
+			"e": 5.0,
+		},
+		"extra": true,
+	}, merged.Raw())
+
+	assert.Equal(t, map[string]interface{}{
+		"name": "foo",
+		"tags": []interface{}{"a", "b"},
+		"meta": map[string]interface{}{
+			"a": 1.0,
+			"b": map[string]interface{}{"c": 2.0, "d": 3.0},
+		},
+	}, value.Raw())
+
+	replaced := value.MergeWith(map[string]interface{}{"meta": "none"})
+	replaced.chain.assertOK(t)
+	replaced.Value("meta").Equal("none").chain.assertOK(t)
+
+	type override struct {
+		Name string `json:"name"`
+	}
+
+	fromStruct := value.MergeWith(override{"bar"})
+	fromStruct.chain.assertOK(t)
+	fromStruct.Value("name").Equal("bar").chain.assertOK(t)
+	fromStruct.Value("tags").Equal([]interface{}{"a", "b"}).chain.assertOK(t)
+
+	bad := value.MergeWith([]interface{}{"foo"})
+	bad.chain.assertFailed(t)
+	value.chain.assertFailed(t)
+	assert.Nil(t, bad.Raw())
+	value.chain.reset()
+}
+
+func TestObjectPathObjectArray(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	m := map[string]interface{}{
+		"foo": map[string]interface{}{
+			"bar": []interface{}{
+				map[string]interface{}{"baz": 123.0},
+			},
+			"qux": "str",
+		},
+	}
+
+	value := NewObject(reporter, m)
+
+	obj := value.PathObject("$.foo")
+	assert.Equal(t, m["foo"], obj.Raw())
+	obj.chain.assertOK(t)
+	value.chain.assertOK(t)
+
+	arr := value.PathArray("$.foo.bar")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"baz": 123.0},
+	}, arr.Raw())
+	arr.chain.assertOK(t)
+	value.chain.assertOK(t)
+
+	value.PathObject("$.foo.bar[0]").ValueEqual("baz", 123)
+	value.chain.assertOK(t)
+
+	obj = value.PathObject("$.foo.bar")
+	obj.chain.assertFailed(t)
+	assert.Nil(t, obj.Raw())
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	arr = value.PathArray("$.foo.qux")
+	arr.chain.assertFailed(t)
+	assert.Nil(t, arr.Raw())
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.PathObject("$.missing").chain.assertFailed(t)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.PathArray("$.missing").chain.assertFailed(t)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestObjectValueNotEqualOrMissing(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"foo": 123,
+		"bar": nil,
+	})
+
+	value.ValueNotEqualOrMissing("foo", 456)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueNotEqualOrMissing("foo", 123)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ValueNotEqualOrMissing("bar", 123)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueNotEqualOrMissing("bar", nil)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ValueNotEqualOrMissing("baz", 123)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueNotEqualOrMissing("baz", func() {})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestObjectValueEqualNum(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"count": 5,
+		"ratio": 0.5,
+		"name":  "5",
+	})
+
+	value.ValueEqualNum("count", 5)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueEqualNum("count", float64(int64(5)))
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueEqualNum("ratio", 0.5)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueEqualNum("count", 6)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ValueEqualNum("name", 5)
+	value.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "string")
+	value.chain.reset()
+
+	value.ValueEqualNum("missing", 5)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestObjectValueEqualIntegerKinds(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{"count": 5})
+
+	for _, n := range []interface{}{
+		int(5), int8(5), int16(5), int32(5), int64(5),
+		uint(5), uint8(5), uint16(5), uint32(5), uint64(5),
+		float32(5), float64(5),
+	} {
+		value.ValueEqual("count", n)
+		value.chain.assertOK(t)
+		value.chain.reset()
+
+		value.ValueNotEqual("count", n)
+		value.chain.assertFailed(t)
+		value.chain.reset()
+
+		value.Value("count").Equal(n).chain.assertOK(t)
+
+		value.Equal(map[string]interface{}{"count": n})
+		value.chain.assertOK(t)
+		value.chain.reset()
+	}
+
+	value.ValueEqual("count", int64(6))
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestObjectValueEqualTime(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"utc":    "2020-01-02T03:04:05Z",
+		"offset": "2020-01-02T05:04:05+02:00",
+		"nano":   "2020-01-02T03:04:05.123456789Z",
+		"bad":    "02 Jan 20 03:04 UTC",
+		"num":    123,
+	})
+
+	utc := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	nano := time.Date(2020, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	other := time.Date(2020, 1, 2, 3, 4, 6, 0, time.UTC)
+	local := utc.In(time.FixedZone("EST", -5*60*60))
+
+	for _, key := range []string{"utc", "offset"} {
+		for _, tm := range []interface{}{utc, &utc, local} {
+			value.ValueEqual(key, tm)
+			value.chain.assertOK(t)
+			value.chain.reset()
+
+			value.ValueNotEqual(key, tm)
+			value.chain.assertFailed(t)
+			value.chain.reset()
+		}
+
+		value.ValueEqual(key, other)
+		value.chain.assertFailed(t)
+		value.chain.reset()
+
+		value.ValueNotEqual(key, other)
+		value.chain.assertOK(t)
+		value.chain.reset()
+	}
+
+	value.ValueEqual("nano", nano)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueEqual("nano", utc)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ValueEqual("bad", utc)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ValueNotEqual("bad", utc)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueEqual("num", utc)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestObjectEqualTime(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"time": "2020-01-02T05:04:05+02:00",
+		"list": []interface{}{"2020-01-02T03:04:05Z"},
+	})
+
+	utc := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	other := time.Date(2020, 1, 2, 3, 4, 6, 0, time.UTC)
+
+	value.Equal(map[string]interface{}{
+		"time": utc,
+		"list": []interface{}{utc.In(time.FixedZone("EST", -5*60*60))},
+	})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.Equal(map[string]interface{}{
+		"time": other,
+		"list": []interface{}{utc},
+	})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ContainsMap(map[string]interface{}{"time": utc})
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ContainsMap(map[string]interface{}{"time": other})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ValueContains("list", utc)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.Value("time").Equal(utc).chain.assertOK(t)
+
+	value.Value("list").Array().Contains(utc).chain.assertOK(t)
+
+	value.Value("list").Array().Contains(other).chain.assertFailed(t)
+
+	value.ValueEqual("time", "2020-01-02T03:04:05Z")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ContainsMap(map[string]interface{}{"time": "2020-01-02T03:04:05Z"})
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	NewArray(reporter, []interface{}{
+		"2020-01-02T05:04:05+02:00",
+		"2020-01-02T03:04:05Z",
+	}).Distinct().chain.assertOK(t)
+}
+
+func TestObjectEqualTimeLayout(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"rfc1123": "Thu, 02 Jan 2020 05:04:05 +0200",
+		"rfc3339": "2020-01-02T03:04:05Z",
+	})
+
+	utc := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	value.ValueEqual("rfc1123", utc)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.chain.timeLayout = time.RFC1123Z
+
+	value.ValueEqual("rfc1123", utc)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueEqual("rfc1123", "Thu, 02 Jan 2020 03:04:05 +0000")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.ValueEqual("rfc3339", utc)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.ValueEqual("rfc1123", utc.Add(time.Second))
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
 func TestObjectValueEqualStruct(t *testing.T) {
 	reporter := newMockReporter(t)
 
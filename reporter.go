@@ -1,7 +1,10 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"sync"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,3 +41,124 @@ func NewRequireReporter(t require.TestingT) *RequireReporter {
 func (r *RequireReporter) Errorf(message string, args ...interface{}) {
 	r.backend.FailNow(fmt.Sprintf(message, args...))
 }
+
+// Failure describes a single reported failure.
+//
+// Message is always set to full failure message, exactly as it's passed
+// to Reporter when no Formatter is set.
+//
+// For failed assertions, AssertionName and AssertType are set as well, and
+// also Expected and Actual, if the assertion has them, e.g. for String.Equal:
+//  AssertionName: "expected string equal to"
+//  AssertType:    "string"
+//  Expected:      "\"foo\""
+//  Actual:        "\"bar\""
+// AssertType is one of "value", "object", "array", "string", "number",
+// "boolean", "datetime", "duration", "cookie", "match", "response", or
+// "message". For other failures, e.g. invalid arguments or request errors,
+// these fields are empty.
+//
+// For failures of nested values, e.g. returned by Object.Value or
+// Array.Element, Path is filled as well, e.g. ".items[3]".
+//
+// For Object.Equal failures, OnlyExpected, OnlyActual, and Different hold
+// sorted keys present only in expected object, only in actual object, and
+// present in both objects but with different values.
+type Failure struct {
+	Path          string   `json:"path,omitempty"`
+	AssertionName string   `json:"assertionName,omitempty"`
+	AssertType    string   `json:"assertType,omitempty"`
+	Expected      string   `json:"expected,omitempty"`
+	Actual        string   `json:"actual,omitempty"`
+	OnlyExpected  []string `json:"onlyExpected,omitempty"`
+	OnlyActual    []string `json:"onlyActual,omitempty"`
+	Different     []string `json:"different,omitempty"`
+	Message       string   `json:"message"`
+}
+
+// JSONReporter implements Reporter interface by writing every failure to
+// io.Writer as a JSON object on a separate line. Failures are non-fatal
+// with this reporter, and it doesn't fail the test by itself, so it is
+// usually used alongside another reporter.
+//
+// Every object is a Failure encoded to JSON, with "path", "assertionName",
+// "assertType", "expected", "actual", "onlyExpected", "onlyActual",
+// "different", and "message" fields. Empty fields are omitted, except
+// "message".
+type JSONReporter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONReporter returns a new JSONReporter object given a writer used
+// to write failures.
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{encoder: json.NewEncoder(w)}
+}
+
+// Errorf implements Reporter.Errorf.
+func (r *JSONReporter) Errorf(message string, args ...interface{}) {
+	r.ReportFailure(Failure{Message: fmt.Sprintf(message, args...)})
+}
+
+// ReportFailure implements FailureReporter.ReportFailure.
+func (r *JSONReporter) ReportFailure(failure Failure) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_ = r.encoder.Encode(failure)
+}
+
+// CollectingReporter implements Reporter interface by recording every
+// failure instead of failing the test. Failures are non-fatal with this
+// reporter.
+//
+// This is useful to run a batch of assertions and then inspect reported
+// failures programmatically, e.g. in a custom test harness.
+//
+// Example:
+//  reporter := httpexpect.NewCollectingReporter()
+//
+//  httpexpect.NewString(reporter, "foo").Equal("bar")
+//
+//  for _, failure := range reporter.Failures() {
+//      fmt.Println(failure.Message)
+//  }
+type CollectingReporter struct {
+	mu       sync.Mutex
+	failures []Failure
+}
+
+// NewCollectingReporter returns a new CollectingReporter object.
+func NewCollectingReporter() *CollectingReporter {
+	return &CollectingReporter{}
+}
+
+// Errorf implements Reporter.Errorf.
+func (r *CollectingReporter) Errorf(message string, args ...interface{}) {
+	r.ReportFailure(Failure{Message: fmt.Sprintf(message, args...)})
+}
+
+// ReportFailure implements FailureReporter.ReportFailure.
+func (r *CollectingReporter) ReportFailure(failure Failure) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures = append(r.failures, failure)
+}
+
+// Failures returns a copy of all failures reported so far, in order.
+func (r *CollectingReporter) Failures() []Failure {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Failure{}, r.failures...)
+}
+
+// Reset forgets all failures reported so far.
+func (r *CollectingReporter) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures = nil
+}
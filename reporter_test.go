@@ -0,0 +1,99 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONReporter(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	reporter := NewJSONReporter(buf)
+
+	NewString(reporter, "bar").Equal("foo")
+	NewObject(reporter, map[string]interface{}{}).ContainsKey("foo")
+	NewNumber(reporter, 1).Equal(2)
+	reporter.Errorf("something went wrong: %d", 42)
+	NewObject(reporter, map[string]interface{}{"items": []interface{}{"x"}}).
+		Value("items").Array().Element(0).Number()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !assert.Equal(t, 5, len(lines)) {
+		return
+	}
+
+	var failures []map[string]string
+	for _, line := range lines {
+		var f map[string]string
+		assert.NoError(t, json.Unmarshal([]byte(line), &f))
+		failures = append(failures, f)
+	}
+
+	assert.Equal(t, "expected string equal to", failures[0]["assertionName"])
+	assert.Equal(t, "string", failures[0]["assertType"])
+	assert.Equal(t, `"foo"`, failures[0]["expected"])
+	assert.Equal(t, `"bar"`, failures[0]["actual"])
+	assert.Contains(t, failures[0]["message"], "but got")
+
+	assert.Equal(t, "expected object containing key 'foo'", failures[1]["assertionName"])
+	assert.Equal(t, "object", failures[1]["assertType"])
+	assert.Equal(t, "", failures[1]["expected"])
+	assert.Equal(t, "{}", failures[1]["actual"])
+
+	assert.Equal(t, "number", failures[2]["assertType"])
+	assert.Equal(t, "2", failures[2]["expected"])
+	assert.Equal(t, "1", failures[2]["actual"])
+
+	assert.Equal(t, map[string]string{
+		"message": "something went wrong: 42",
+	}, failures[3])
+
+	assert.Equal(t, ".items[0]", failures[4]["path"])
+	assert.Equal(t, "expected numeric value", failures[4]["assertionName"])
+	assert.Equal(t, "value", failures[4]["assertType"])
+	assert.Equal(t, `"x"`, failures[4]["actual"])
+	assert.Equal(t, "", failures[0]["path"])
+}
+
+func TestCollectingReporter(t *testing.T) {
+	reporter := NewCollectingReporter()
+
+	assert.Equal(t, []Failure{}, reporter.Failures())
+
+	NewString(reporter, "bar").Equal("foo")
+	NewString(reporter, "bar").Equal("bar")
+	NewObject(reporter, map[string]interface{}{"items": []interface{}{"x"}}).
+		Value("items").Array().Element(0).Number()
+	reporter.Errorf("something went wrong: %d", 42)
+
+	failures := reporter.Failures()
+	if !assert.Equal(t, 3, len(failures)) {
+		return
+	}
+
+	assert.Equal(t, "", failures[0].Path)
+	assert.Equal(t, "expected string equal to", failures[0].AssertionName)
+	assert.Equal(t, "string", failures[0].AssertType)
+	assert.Equal(t, `"foo"`, failures[0].Expected)
+	assert.Equal(t, `"bar"`, failures[0].Actual)
+	assert.Equal(t,
+		"\nexpected string equal to:\n \"foo\"\n\nbut got:\n \"bar\"",
+		failures[0].Message)
+
+	assert.Equal(t, ".items[0]", failures[1].Path)
+	assert.Equal(t, "expected numeric value", failures[1].AssertionName)
+	assert.Equal(t, "value", failures[1].AssertType)
+	assert.Equal(t, `"x"`, failures[1].Actual)
+
+	assert.Equal(t, Failure{Message: "something went wrong: 42"}, failures[2])
+
+	failures[0].Message = "modified"
+	assert.NotEqual(t, "modified", reporter.Failures()[0].Message)
+
+	reporter.Reset()
+	assert.Equal(t, []Failure{}, reporter.Failures())
+}
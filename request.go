@@ -2,12 +2,14 @@ package httpexpect
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"net/http/httputil"
 	"net/url"
 	"os"
 	"reflect"
@@ -37,7 +39,13 @@ type Request struct {
 	typeSetter string
 	forceType  bool
 	wsUpgrade  bool
+	dumpOnFail bool
 	matchers   []func(*Response)
+
+	maxRetries   int
+	retryOn      func(*http.Response, error) bool
+	retryBackoff func(attempt int) time.Duration
+	attempts     int
 }
 
 // NewRequest returns a new Request object.
@@ -72,7 +80,7 @@ func NewRequest(config Config, method, path string, pathargs ...interface{}) *Re
 		panic("config.Client == nil")
 	}
 
-	chain := makeChain(config.Reporter)
+	chain := makeConfigChain(config)
 
 	n := 0
 	path, err := interpol.WithFunc(path, func(k string, w io.Writer) error {
@@ -123,6 +131,113 @@ func (r *Request) WithMatcher(matcher func(*Response)) *Request {
 	return r
 }
 
+// WithDumpOnFailure enables dumping of the request to failure reports.
+//
+// If enabled, the request is serialized (method, URL, headers, and body)
+// right before it is sent, and the dump is appended to every failure
+// reported by the returned Response and objects retrieved from it.
+// Nothing is printed if all assertions succeed.
+//
+// Example:
+//  req := NewRequest(config, "POST", "/path")
+//  req.WithDumpOnFailure()
+//  req.WithJSON(map[string]interface{}{"foo": 123})
+//  req.Expect().Status(http.StatusOK) // request is dumped if status differs
+func (r *Request) WithDumpOnFailure() *Request {
+	if r.chain.failed() {
+		return r
+	}
+	r.dumpOnFail = true
+	return r
+}
+
+// WithContext sets the context of the request.
+//
+// The context controls the entire lifetime of the request: if it's
+// cancelled or its deadline is exceeded, the request is aborted and
+// failure is reported. If the context is already done when Expect is
+// called, the request is not sent at all.
+//
+// Example:
+//  ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//  defer cancel()
+//
+//  req := NewRequest(config, "GET", "/path")
+//  req.WithContext(ctx)
+//  req.Expect().Status(http.StatusOK)
+func (r *Request) WithContext(ctx context.Context) *Request {
+	if r.chain.failed() {
+		return r
+	}
+	if ctx == nil {
+		r.chain.fail("\nunexpected nil context in WithContext")
+		return r
+	}
+	r.http = r.http.WithContext(ctx)
+	return r
+}
+
+// WithRetry enables retrying the request.
+//
+// When Expect is called, the request is sent and then re-sent up to maxRetries
+// times while retryOn returns true for the received response or error. The
+// returned Response reflects the last attempt, and the number of attempts may
+// be inspected using Response.Attempts. If retryOn is nil, the request is
+// retried on transport errors and 5xx responses.
+//
+// Request body is buffered before the first attempt, so that it can be sent
+// again. Between attempts, request waits for the duration returned by the
+// backoff function (see WithRetryBackoff). Response round-trip time covers
+// all attempts, including delays between them.
+//
+// Retries are not supported for WebSocket requests and are ignored for them.
+//
+// Example:
+//  req := NewRequest(config, "GET", "/path")
+//  req.WithRetry(3, func(resp *http.Response, err error) bool {
+//      return err != nil || resp.StatusCode == http.StatusNotFound
+//  })
+//  req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetry(
+	maxRetries int, retryOn func(*http.Response, error) bool,
+) *Request {
+	if r.chain.failed() {
+		return r
+	}
+	if maxRetries < 0 {
+		r.chain.fail("\nunexpected negative maxRetries passed to WithRetry:\n %d",
+			maxRetries)
+		return r
+	}
+	r.maxRetries = maxRetries
+	r.retryOn = retryOn
+	return r
+}
+
+// WithRetryBackoff sets function that returns delay before given retry attempt.
+//
+// attempt is 1 for the first retry, 2 for the second retry, and so on. If
+// backoff is not set, delay starts from 100ms and doubles on every attempt,
+// up to 5s. Backoff is used only if retries are enabled using WithRetry.
+//
+// Example:
+//  req := NewRequest(config, "GET", "/path")
+//  req.WithRetry(3, nil)
+//  req.WithRetryBackoff(func(attempt int) time.Duration {
+//      return time.Second
+//  })
+func (r *Request) WithRetryBackoff(backoff func(attempt int) time.Duration) *Request {
+	if r.chain.failed() {
+		return r
+	}
+	if backoff == nil {
+		r.chain.fail("\nunexpected nil backoff in WithRetryBackoff")
+		return r
+	}
+	r.retryBackoff = backoff
+	return r
+}
+
 // WithClient sets client.
 //
 // The new client overwrites Config.Client. It will be used once to send the
@@ -540,6 +655,93 @@ func (r *Request) WithBasicAuth(username, password string) *Request {
 	return r
 }
 
+// WithBearerToken sets the request's Authorization header to use HTTP
+// Bearer Authentication with the provided token.
+//
+// Example:
+//  req := NewRequest(config, "PUT", "http://example.com/path")
+//  req.WithBearerToken("my-token")
+func (r *Request) WithBearerToken(token string) *Request {
+	if r.chain.failed() {
+		return r
+	}
+	r.http.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+// WithIdempotencyKey sets the request's Idempotency-Key header.
+//
+// If key is omitted, a random UUID (version 4) is generated. The key is
+// stored in the request headers, so it stays the same if the request is
+// sent multiple times, e.g. when it is retried, which allows the server
+// to recognize repeated attempts of the same operation.
+//
+// Example:
+//  req := NewRequest(config, "POST", "http://example.com/payments")
+//  req.WithIdempotencyKey()
+//
+//  req := NewRequest(config, "POST", "http://example.com/payments")
+//  req.WithIdempotencyKey("8e03978e-40d5-43e8-bc93-6894a57f9324")
+func (r *Request) WithIdempotencyKey(key ...string) *Request {
+	if r.chain.failed() {
+		return r
+	}
+	if len(key) > 1 {
+		r.chain.fail(
+			"\nunexpected multiple key arguments passed to WithIdempotencyKey")
+		return r
+	}
+	var value string
+	if len(key) == 1 {
+		if key[0] == "" {
+			r.chain.fail("\nunexpected empty key passed to WithIdempotencyKey")
+			return r
+		}
+		value = key[0]
+	} else {
+		uuid, err := newUUID()
+		if err != nil {
+			r.chain.fail(err.Error())
+			return r
+		}
+		value = uuid
+	}
+	r.http.Header.Set("Idempotency-Key", value)
+	return r
+}
+
+// WithMethodOverride sets X-HTTP-Method-Override header to given method and
+// changes request method to POST.
+//
+// This is useful for testing servers that allow tunneling PUT, DELETE, and
+// other methods through POST requests, e.g. when clients are behind proxies
+// that allow only GET and POST.
+//
+// method should be a known HTTP method, like "PUT" or "DELETE" (case is
+// ignored), otherwise failure is reported.
+//
+// Example:
+//  req := NewRequest(config, "PUT", "http://example.com/path")
+//  req.WithMethodOverride("DELETE")
+func (r *Request) WithMethodOverride(method string) *Request {
+	if r.chain.failed() {
+		return r
+	}
+	m := strings.ToUpper(method)
+	switch m {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodConnect,
+		http.MethodOptions, http.MethodTrace:
+	default:
+		r.chain.fail("\nunexpected HTTP method passed to WithMethodOverride:\n %q",
+			method)
+		return r
+	}
+	r.http.Method = http.MethodPost
+	r.http.Header.Set("X-HTTP-Method-Override", m)
+	return r
+}
+
 // WithProto sets HTTP protocol version.
 //
 // proto should have form of "HTTP/{major}.{minor}", e.g. "HTTP/1.1".
@@ -714,7 +916,10 @@ func (r *Request) WithForm(object interface{}) *Request {
 
 // WithFormField sets Content-Type header to "application/x-www-form-urlencoded"
 // or (if WithMultipart() was called) "multipart/form-data", converts given
-// value to string using fmt.Sprint(), and adds it to request body.
+// values to strings using fmt.Sprint(), and adds them to request body.
+//
+// If multiple values are given, the field is added multiple times, once
+// per value, in the given order. At least one value should be given.
 //
 // Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
 // If WithMultipart() is called, it should be called first.
@@ -723,17 +928,27 @@ func (r *Request) WithForm(object interface{}) *Request {
 //  req := NewRequest(config, "PUT", "http://example.com/path")
 //  req.WithFormField("foo", 123).
 //      WithFormField("bar", 456)
-func (r *Request) WithFormField(key string, value interface{}) *Request {
+//
+//  req := NewRequest(config, "PUT", "http://example.com/path")
+//  req.WithFormField("tags", "foo", "bar")
+func (r *Request) WithFormField(key string, values ...interface{}) *Request {
 	if r.chain.failed() {
 		return r
 	}
+	if len(values) == 0 {
+		r.chain.fail(
+			"\nunexpected empty values passed to WithFormField for key %q", key)
+		return r
+	}
 	if r.multipart != nil {
 		r.setType("WithFormField", "multipart/form-data", false)
 
-		err := r.multipart.WriteField(key, fmt.Sprint(value))
-		if err != nil {
-			r.chain.fail(err.Error())
-			return r
+		for _, value := range values {
+			err := r.multipart.WriteField(key, fmt.Sprint(value))
+			if err != nil {
+				r.chain.fail(err.Error())
+				return r
+			}
 		}
 	} else {
 		r.setType("WithFormField", "application/x-www-form-urlencoded", false)
@@ -741,7 +956,9 @@ func (r *Request) WithFormField(key string, value interface{}) *Request {
 		if r.form == nil {
 			r.form = make(url.Values)
 		}
-		r.form[key] = append(r.form[key], fmt.Sprint(value))
+		for _, value := range values {
+			r.form[key] = append(r.form[key], fmt.Sprint(value))
+		}
 	}
 	return r
 }
@@ -863,12 +1080,12 @@ func (r *Request) WithMultipart() *Request {
 //  resp := req.Expect()
 //  resp.Status(http.StatusOK)
 func (r *Request) Expect() *Response {
-	resp := r.roundTrip()
+	resp, respChain := r.roundTrip()
 
 	if resp == nil {
 		return makeResponse(responseOpts{
 			config: r.config,
-			chain:  r.chain,
+			chain:  respChain,
 		})
 	}
 
@@ -879,17 +1096,31 @@ func (r *Request) Expect() *Response {
 	return resp
 }
 
-func (r *Request) roundTrip() *Response {
+// roundTrip sends request and returns response, or nil on failure. It also
+// returns chain for the response, which reports request dump on failures if
+// it is enabled; failures that happen after the dump is taken are reported
+// using this chain as well.
+func (r *Request) roundTrip() (*Response, chain) {
 	if !r.encodeRequest() {
-		return nil
+		return nil, r.chain.clone()
 	}
 
 	if r.wsUpgrade {
 		if !r.encodeWebsocketRequest() {
-			return nil
+			return nil, r.chain.clone()
 		}
 	}
 
+	respChain := r.chain.clone()
+	if r.dumpOnFail {
+		dump, err := httputil.DumpRequest(r.http, !r.wsUpgrade)
+		if err != nil {
+			r.chain.fail(err.Error())
+			return nil, r.chain.clone()
+		}
+		respChain.requestDump = strings.TrimRight(string(dump), "\r\n")
+	}
+
 	for _, printer := range r.config.Printers {
 		printer.Request(r.http)
 	}
@@ -901,15 +1132,18 @@ func (r *Request) roundTrip() *Response {
 		websock  *websocket.Conn
 	)
 	if r.wsUpgrade {
-		httpResp, websock = r.sendWebsocketRequest()
+		httpResp, websock = r.sendWebsocketRequest(&respChain)
 	} else {
-		httpResp = r.sendRequest()
+		httpResp = r.sendRequest(&respChain)
 	}
 
 	elapsed := time.Since(start)
 
 	if httpResp == nil {
-		return nil
+		if respChain.failed() {
+			r.chain.setFailed()
+		}
+		return nil, respChain
 	}
 
 	for _, printer := range r.config.Printers {
@@ -918,11 +1152,12 @@ func (r *Request) roundTrip() *Response {
 
 	return makeResponse(responseOpts{
 		config:    r.config,
-		chain:     r.chain,
+		chain:     respChain,
 		response:  httpResp,
 		websocket: websock,
 		rtt:       &elapsed,
-	})
+		attempts:  r.attempts,
+	}), respChain
 }
 
 func (r *Request) encodeRequest() bool {
@@ -931,6 +1166,7 @@ func (r *Request) encodeRequest() bool {
 	}
 
 	r.http.URL.Path = concatPaths(r.http.URL.Path, r.path)
+	r.chain.requestPath = r.http.URL.Path
 
 	if r.query != nil {
 		r.http.URL.RawQuery = r.query.Encode()
@@ -975,23 +1211,131 @@ func (r *Request) encodeWebsocketRequest() bool {
 	return true
 }
 
-func (r *Request) sendRequest() *http.Response {
-	if r.chain.failed() {
+func (r *Request) sendRequest(c *chain) *http.Response {
+	if !r.checkContext(c) {
 		return nil
 	}
 
-	resp, err := r.config.Client.Do(r.http)
+	if r.maxRetries == 0 {
+		r.attempts = 1
+
+		resp, err := r.config.Client.Do(r.http)
+
+		if err != nil {
+			c.fail(r.describeError(err))
+			return nil
+		}
+
+		return resp
+	}
+
+	var body []byte
+	if r.http.Body != nil && r.http.Body != http.NoBody {
+		b, err := ioutil.ReadAll(r.http.Body)
+		if err != nil {
+			c.fail(err.Error())
+			return nil
+		}
+		_ = r.http.Body.Close()
+		body = b
+		r.http.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	retryOn := r.retryOn
+	if retryOn == nil {
+		retryOn = defaultRetryOn
+	}
+
+	backoff := r.retryBackoff
+	if backoff == nil {
+		backoff = defaultRetryBackoff
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+	for r.attempts = 1; ; r.attempts++ {
+		if body != nil {
+			r.http.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err = r.config.Client.Do(r.http)
+
+		if r.attempts > r.maxRetries || !retryOn(resp, err) {
+			break
+		}
+
+		if resp != nil && resp.Body != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff(r.attempts))
+		select {
+		case <-timer.C:
+			continue
+		case <-r.http.Context().Done():
+			timer.Stop()
+		}
+
+		resp, err = nil, r.http.Context().Err()
+		break
+	}
 
 	if err != nil {
-		r.chain.fail(err.Error())
+		c.fail("%s\n\nafter %d attempt(s)", r.describeError(err), r.attempts)
 		return nil
 	}
 
 	return resp
 }
 
-func (r *Request) sendWebsocketRequest() (*http.Response, *websocket.Conn) {
-	if r.chain.failed() {
+func (r *Request) checkContext(c *chain) bool {
+	if c.failed() {
+		return false
+	}
+
+	if err := r.http.Context().Err(); err != nil {
+		c.fail(
+			"\nrequest context is done before sending request:\n %s", err.Error())
+		return false
+	}
+
+	return true
+}
+
+func (r *Request) describeError(err error) string {
+	if ctxErr := r.http.Context().Err(); ctxErr != nil {
+		return fmt.Sprintf(
+			"\nrequest aborted because request context is done:\n %s\n\nerror:\n %s",
+			ctxErr.Error(), err.Error())
+	}
+	return err.Error()
+}
+
+func defaultRetryOn(resp *http.Response, err error) bool {
+	return err != nil || (resp != nil && resp.StatusCode >= 500)
+}
+
+func defaultRetryBackoff(attempt int) time.Duration {
+	const (
+		minDelay = 100 * time.Millisecond
+		maxDelay = 5 * time.Second
+	)
+	if attempt > 6 {
+		return maxDelay
+	}
+	if d := minDelay << uint(attempt-1); d < maxDelay {
+		return d
+	}
+	return maxDelay
+}
+
+func (r *Request) sendWebsocketRequest(c *chain) (*http.Response, *websocket.Conn) {
+	if !r.checkContext(c) {
 		return nil, nil
 	}
 
@@ -999,7 +1343,7 @@ func (r *Request) sendWebsocketRequest() (*http.Response, *websocket.Conn) {
 		r.http.URL.String(), r.http.Header)
 
 	if err != nil && err != websocket.ErrBadHandshake {
-		r.chain.fail(err.Error())
+		c.fail(err.Error())
 		return nil, nil
 	}
 
@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -9,8 +10,10 @@ import (
 	"mime/multipart"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,6 +36,10 @@ func TestRequestFailed(t *testing.T) {
 		http:   nil,
 	}
 
+	req.WithDumpOnFailure()
+	req.WithContext(context.Background())
+	req.WithRetry(1, nil)
+	req.WithRetryBackoff(func(int) time.Duration { return 0 })
 	req.WithClient(&http.Client{})
 	req.WithHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
 	req.WithPath("foo", "bar")
@@ -46,6 +53,9 @@ func TestRequestFailed(t *testing.T) {
 	req.WithCookies(map[string]string{"foo": "bar"})
 	req.WithCookie("foo", "bar")
 	req.WithBasicAuth("foo", "bar")
+	req.WithBearerToken("foo")
+	req.WithIdempotencyKey()
+	req.WithMethodOverride("PUT")
 	req.WithProto("HTTP/1.1")
 	req.WithChunked(strings.NewReader("foo"))
 	req.WithBytes([]byte("foo"))
@@ -137,6 +147,86 @@ func TestRequestMatchers(t *testing.T) {
 	assert.Equal(t, resp, resps[0])
 }
 
+func TestRequestDumpOnFailure(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	req1 := NewRequest(config, "PUT", "http://example.com/path")
+	req1.WithHeader("X-Test", "foo")
+	req1.WithText("some body")
+
+	resp1 := req1.Expect()
+	resp1.Status(http.StatusTeapot)
+	resp1.chain.assertFailed(t)
+
+	assert.NotContains(t, reporter.message, "request:")
+
+	req2 := NewRequest(config, "PUT", "http://example.com/path")
+	req2.WithDumpOnFailure()
+	req2.WithHeader("X-Test", "foo")
+	req2.WithText("some body")
+
+	reporter.message = ""
+
+	resp2 := req2.Expect()
+	resp2.chain.assertOK(t)
+	assert.Equal(t, "", reporter.message)
+
+	resp2.Body().Equal("some body")
+	resp2.chain.assertOK(t)
+	assert.Equal(t, "", reporter.message)
+
+	resp2.Status(http.StatusTeapot)
+	resp2.chain.assertFailed(t)
+
+	assert.Contains(t, reporter.message, "\n\nrequest:\n")
+	assert.Contains(t, reporter.message, "PUT http://example.com/path HTTP/1.1")
+	assert.Contains(t, reporter.message, "X-Test: foo")
+	assert.Contains(t, reporter.message, "some body")
+}
+
+func TestRequestDumpOnFailureClientError(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{
+		err: errors.New("connection refused"),
+	}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	req := NewRequest(config, "PUT", "http://example.com/path")
+	req.WithDumpOnFailure()
+	req.WithHeader("X-Test", "foo")
+	req.WithText("some body")
+
+	resp := req.Expect()
+	req.chain.assertFailed(t)
+	resp.chain.assertFailed(t)
+
+	assert.Contains(t, reporter.message, "connection refused")
+	assert.Contains(t, reporter.message, "\n\nrequest:\n")
+	assert.Contains(t, reporter.message, "PUT http://example.com/path HTTP/1.1")
+	assert.Contains(t, reporter.message, "X-Test: foo")
+	assert.Contains(t, reporter.message, "some body")
+
+	assert.NotEmpty(t, resp.chain.requestDump)
+}
+
 func TestRequestClient(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -632,6 +722,415 @@ func TestRequestBasicAuth(t *testing.T) {
 		req.http.Header.Get("Authorization"))
 }
 
+func TestRequestRetry(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	reporter := newMockReporter(t)
+
+	noDelay := func(int) time.Duration { return 0 }
+
+	newClient := func(statuses ...int) (Client, *[]string) {
+		var bodies []string
+		return mockClientFunc(func(req *http.Request) (*http.Response, error) {
+			var b []byte
+			if req.Body != nil {
+				b, _ = ioutil.ReadAll(req.Body)
+			}
+			bodies = append(bodies, string(b))
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			if status == 0 {
+				return nil, errors.New("connection refused")
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}), &bodies
+	}
+
+	t.Run("success after retries", func(t *testing.T) {
+		client, bodies := newClient(503, 0, 200)
+
+		config := Config{
+			RequestFactory: factory,
+			Client:         client,
+			Reporter:       reporter,
+		}
+
+		req := NewRequest(config, "POST", "url")
+		req.WithText("body")
+		req.WithRetry(5, nil)
+		req.WithRetryBackoff(noDelay)
+
+		resp := req.Expect()
+		resp.chain.assertOK(t)
+
+		resp.Status(http.StatusOK).chain.assertOK(t)
+		resp.Body().Equal("body").chain.assertOK(t)
+		resp.Attempts().Equal(3).chain.assertOK(t)
+
+		assert.Equal(t, []string{"body", "body", "body"}, *bodies)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		client, bodies := newClient(503)
+
+		config := Config{
+			RequestFactory: factory,
+			Client:         client,
+			Reporter:       reporter,
+		}
+
+		req := NewRequest(config, "GET", "url")
+		req.WithRetry(2, nil)
+		req.WithRetryBackoff(noDelay)
+
+		resp := req.Expect()
+		resp.chain.assertOK(t)
+
+		resp.Status(http.StatusServiceUnavailable).chain.assertOK(t)
+		resp.Attempts().Equal(3).chain.assertOK(t)
+
+		assert.Equal(t, 3, len(*bodies))
+	})
+
+	t.Run("error after retries", func(t *testing.T) {
+		client, _ := newClient(0)
+
+		config := Config{
+			RequestFactory: factory,
+			Client:         client,
+			Reporter:       reporter,
+		}
+
+		req := NewRequest(config, "GET", "url")
+		req.WithRetry(1, nil)
+		req.WithRetryBackoff(noDelay)
+
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		assert.Contains(t, reporter.message, "after 2 attempt(s)")
+	})
+
+	t.Run("custom predicate", func(t *testing.T) {
+		client, _ := newClient(404, 404, 200)
+
+		config := Config{
+			RequestFactory: factory,
+			Client:         client,
+			Reporter:       reporter,
+		}
+
+		var delays []int
+
+		req := NewRequest(config, "GET", "url")
+		req.WithRetry(5, func(resp *http.Response, err error) bool {
+			return err == nil && resp.StatusCode == http.StatusNotFound
+		})
+		req.WithRetryBackoff(func(attempt int) time.Duration {
+			delays = append(delays, attempt)
+			return 0
+		})
+
+		resp := req.Expect()
+		resp.chain.assertOK(t)
+
+		resp.Status(http.StatusOK).chain.assertOK(t)
+		resp.Attempts().Equal(3).chain.assertOK(t)
+
+		assert.Equal(t, []int{1, 2}, delays)
+	})
+
+	t.Run("no retries", func(t *testing.T) {
+		client, _ := newClient(503, 200)
+
+		config := Config{
+			RequestFactory: factory,
+			Client:         client,
+			Reporter:       reporter,
+		}
+
+		req := NewRequest(config, "GET", "url")
+
+		resp := req.Expect()
+		resp.chain.assertOK(t)
+
+		resp.Status(http.StatusServiceUnavailable).chain.assertOK(t)
+		resp.Attempts().Equal(1).chain.assertOK(t)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		config := Config{
+			RequestFactory: factory,
+			Client:         &mockClient{},
+			Reporter:       reporter,
+		}
+
+		req1 := NewRequest(config, "GET", "url")
+		req1.WithRetry(-1, nil)
+		req1.chain.assertFailed(t)
+
+		req2 := NewRequest(config, "GET", "url")
+		req2.WithRetryBackoff(nil)
+		req2.chain.assertFailed(t)
+	})
+}
+
+func TestRequestPreserveNumbers(t *testing.T) {
+	config := Config{
+		RequestFactory: DefaultRequestFactory{},
+		Client:         &mockClient{},
+		Reporter:       newMockReporter(t),
+	}
+
+	req1 := NewRequest(config, "GET", "url")
+	assert.False(t, req1.chain.preserveNumbers)
+	assert.False(t, req1.Expect().chain.preserveNumbers)
+
+	config.PreserveNumbers = true
+
+	req2 := NewRequest(config, "GET", "url")
+	assert.True(t, req2.chain.preserveNumbers)
+	assert.True(t, req2.Expect().chain.preserveNumbers)
+}
+
+func TestRequestContext(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	reporter := newMockReporter(t)
+
+	t.Run("attached", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			RequestFactory: factory,
+			Client:         client,
+			Reporter:       reporter,
+		}
+
+		type ctxKey struct{}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+		req := NewRequest(config, "GET", "url")
+		req.WithContext(ctx)
+		req.chain.assertOK(t)
+
+		req.Expect().chain.assertOK(t)
+
+		assert.Equal(t, "value", client.req.Context().Value(ctxKey{}))
+	})
+
+	t.Run("cancelled before sending", func(t *testing.T) {
+		called := false
+
+		config := Config{
+			RequestFactory: factory,
+			Client: mockClientFunc(func(*http.Request) (*http.Response, error) {
+				called = true
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			}),
+			Reporter: reporter,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req := NewRequest(config, "GET", "url")
+		req.WithContext(ctx)
+		req.chain.assertOK(t)
+
+		req.Expect().chain.assertFailed(t)
+
+		assert.False(t, called)
+		assert.Contains(t, reporter.message, "request context is done")
+		assert.Contains(t, reporter.message, context.Canceled.Error())
+	})
+
+	t.Run("cancelled while sending", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		config := Config{
+			RequestFactory: factory,
+			Client: mockClientFunc(func(req *http.Request) (*http.Response, error) {
+				cancel()
+				return nil, errors.New("transport error")
+			}),
+			Reporter: reporter,
+		}
+
+		req := NewRequest(config, "GET", "url")
+		req.WithContext(ctx)
+
+		req.Expect().chain.assertFailed(t)
+
+		assert.Contains(t, reporter.message, "request context is done")
+		assert.Contains(t, reporter.message, "transport error")
+	})
+
+	t.Run("cancelled while retrying", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		attempts := 0
+
+		config := Config{
+			RequestFactory: factory,
+			Client: mockClientFunc(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				cancel()
+				return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+			}),
+			Reporter: reporter,
+		}
+
+		req := NewRequest(config, "GET", "url")
+		req.WithContext(ctx)
+		req.WithRetry(5, nil)
+		req.WithRetryBackoff(func(int) time.Duration { return time.Hour })
+
+		req.Expect().chain.assertFailed(t)
+
+		assert.Equal(t, 1, attempts)
+		assert.Contains(t, reporter.message, "request context is done")
+	})
+
+	t.Run("nil context", func(t *testing.T) {
+		config := Config{
+			RequestFactory: factory,
+			Client:         &mockClient{},
+			Reporter:       reporter,
+		}
+
+		req := NewRequest(config, "GET", "url")
+		req.WithContext(nil) // nolint
+		req.chain.assertFailed(t)
+	})
+}
+
+func TestRequestRetryDefaultBackoff(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, defaultRetryBackoff(1))
+	assert.Equal(t, 200*time.Millisecond, defaultRetryBackoff(2))
+	assert.Equal(t, 3200*time.Millisecond, defaultRetryBackoff(6))
+	assert.Equal(t, 5*time.Second, defaultRetryBackoff(7))
+	assert.Equal(t, 5*time.Second, defaultRetryBackoff(100))
+}
+
+func TestRequestBearerToken(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	req := NewRequest(config, "METHOD", "url")
+
+	req.WithBasicAuth("Aladdin", "open sesame")
+	req.WithBearerToken("mF_9.B5f-4.1JqM")
+	req.chain.assertOK(t)
+
+	assert.Equal(t, []string{"Bearer mF_9.B5f-4.1JqM"},
+		req.http.Header["Authorization"])
+}
+
+func TestRequestIdempotencyKey(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	uuidRe := regexp.MustCompile(
+		`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	req1 := NewRequest(config, "POST", "url")
+	req1.WithIdempotencyKey()
+	req1.chain.assertOK(t)
+
+	key1 := req1.http.Header.Get("Idempotency-Key")
+	assert.Regexp(t, uuidRe, key1)
+
+	req1.Expect().chain.assertOK(t)
+	assert.Equal(t, key1, client.req.Header.Get("Idempotency-Key"))
+
+	req2 := NewRequest(config, "POST", "url")
+	req2.WithIdempotencyKey()
+	req2.chain.assertOK(t)
+
+	key2 := req2.http.Header.Get("Idempotency-Key")
+	assert.Regexp(t, uuidRe, key2)
+	assert.NotEqual(t, key1, key2)
+
+	req3 := NewRequest(config, "POST", "url")
+	req3.WithIdempotencyKey("my-key")
+	req3.WithIdempotencyKey("other-key")
+	req3.chain.assertOK(t)
+	assert.Equal(t, []string{"other-key"}, req3.http.Header["Idempotency-Key"])
+
+	req4 := NewRequest(config, "POST", "url")
+	req4.WithIdempotencyKey("")
+	req4.chain.assertFailed(t)
+
+	req5 := NewRequest(config, "POST", "url")
+	req5.WithIdempotencyKey("a", "b")
+	req5.chain.assertFailed(t)
+}
+
+func TestRequestMethodOverride(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	req1 := NewRequest(config, "PUT", "url")
+	req1.WithMethodOverride("DELETE")
+	req1.chain.assertOK(t)
+
+	req1.Expect().chain.assertOK(t)
+
+	assert.Equal(t, "POST", client.req.Method)
+	assert.Equal(t, "DELETE", client.req.Header.Get("X-HTTP-Method-Override"))
+
+	req2 := NewRequest(config, "GET", "url")
+	req2.WithMethodOverride("patch")
+	req2.chain.assertOK(t)
+
+	assert.Equal(t, "POST", req2.http.Method)
+	assert.Equal(t, "PATCH", req2.http.Header.Get("X-HTTP-Method-Override"))
+
+	req3 := NewRequest(config, "GET", "url")
+	req3.WithMethodOverride("FOO")
+	req3.chain.assertFailed(t)
+
+	assert.Equal(t, "GET", req3.http.Method)
+	assert.Equal(t, "", req3.http.Header.Get("X-HTTP-Method-Override"))
+
+	req4 := NewRequest(config, "GET", "url")
+	req4.WithMethodOverride("")
+	req4.chain.assertFailed(t)
+}
+
 func TestRequestBodyChunked(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -890,6 +1389,57 @@ func TestRequestBodyField(t *testing.T) {
 	assert.Equal(t, &client.resp, resp.Raw())
 }
 
+func TestRequestBodyFormFieldMultiple(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	req1 := NewRequest(config, "METHOD", "url")
+
+	req1.WithFormField("a", 1, "x", true)
+	req1.WithFormField("b", 2)
+	req1.WithFormField("a", 3)
+
+	resp := req1.Expect()
+	resp.chain.assertOK(t)
+
+	assert.Equal(t, `a=1&a=x&a=true&a=3&b=2`, string(resp.content))
+
+	req2 := NewRequest(config, "POST", "url")
+
+	req2.WithMultipart()
+	req2.WithFormField("a", 1, 2)
+
+	resp = req2.Expect()
+	resp.chain.assertOK(t)
+
+	_, params, err := mime.ParseMediaType(client.req.Header.Get("Content-Type"))
+	require.Nil(t, err)
+
+	reader := multipart.NewReader(bytes.NewReader(resp.content), params["boundary"])
+
+	for _, expected := range []string{"1", "2"} {
+		part, err := reader.NextPart()
+		require.Nil(t, err)
+		assert.Equal(t, "a", part.FormName())
+		b, _ := ioutil.ReadAll(part)
+		assert.Equal(t, expected, string(b))
+	}
+
+	req3 := NewRequest(config, "METHOD", "url")
+
+	req3.WithFormField("a")
+	req3.chain.assertFailed(t)
+}
+
 func TestRequestBodyFormStruct(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -1453,3 +2003,26 @@ func TestRequestErrorConflictMultipart(t *testing.T) {
 	req3.WithFileBytes("a", "a", []byte("a"))
 	req3.chain.assertFailed(t)
 }
+
+func TestRequestFormatter(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: DefaultRequestFactory{},
+		Client: mockClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"foo":1}`))),
+			}, nil
+		}),
+		Reporter:  reporter,
+		Formatter: mockFormatter{},
+	}
+
+	resp := NewRequest(config, "GET", "url").Expect()
+
+	resp.JSON().Object().Value("foo").String()
+	resp.chain.assertOK(t)
+	assert.Equal(t, "[.foo] expected string value", reporter.message)
+}
@@ -2,10 +2,11 @@ package httpexpect
 
 import (
 	"bytes"
-	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"net/textproto"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -45,6 +46,7 @@ type Response struct {
 	cookies   []*http.Cookie
 	websocket *websocket.Conn
 	rtt       *time.Duration
+	attempts  int
 }
 
 // NewResponse returns a new Response given a reporter used to report
@@ -75,9 +77,14 @@ type responseOpts struct {
 	response  *http.Response
 	websocket *websocket.Conn
 	rtt       *time.Duration
+	attempts  int
 }
 
 func makeResponse(opts responseOpts) *Response {
+	attempts := opts.attempts
+	if attempts == 0 {
+		attempts = 1
+	}
 	var content []byte
 	var cookies []*http.Cookie
 	if opts.response != nil {
@@ -94,6 +101,7 @@ func makeResponse(opts responseOpts) *Response {
 		cookies:   cookies,
 		websocket: opts.websocket,
 		rtt:       opts.rtt,
+		attempts:  attempts,
 	}
 }
 
@@ -108,12 +116,22 @@ func getContent(chain *chain, resp *http.Response) []byte {
 		return nil
 	}
 
+	_ = resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(content))
+
 	return content
 }
 
 // Raw returns underlying http.Response object.
 // This is the value originally passed to NewResponse.
+//
+// Response body is already read and buffered when Response is created.
+// Body of returned object is replaced with a reader of buffered content,
+// which is rewound on every call, so the body may be read again.
 func (r *Response) Raw() *http.Response {
+	if r.resp != nil && r.content != nil {
+		r.resp.Body = ioutil.NopCloser(bytes.NewReader(r.content))
+	}
 	return r.resp
 }
 
@@ -128,15 +146,28 @@ func (r *Response) Raw() *http.Response {
 //  resp := NewResponse(t, response, time.Duration(10000000))
 //  resp.RoundTripTime().Lt(10 * time.Millisecond)
 func (r *Response) RoundTripTime() *Duration {
-	return &Duration{r.chain, r.rtt}
+	return &Duration{r.chain.clone(), r.rtt}
+}
+
+// Attempts returns a new Number object that may be used to inspect
+// the number of times the request was sent.
+//
+// It is greater than one only if retries were enabled using
+// Request.WithRetry and some attempts were retried.
+//
+// Example:
+//  resp := req.WithRetry(3, nil).Expect()
+//  resp.Attempts().Le(2)
+func (r *Response) Attempts() *Number {
+	return &Number{r.chain.clone(), float64(r.attempts), nil}
 }
 
 // Deprecated: use RoundTripTime instead.
 func (r *Response) Duration() *Number {
 	if r.rtt == nil {
-		return &Number{r.chain, 0}
+		return &Number{r.chain.clone(), 0, nil}
 	}
-	return &Number{r.chain, float64(*r.rtt)}
+	return &Number{r.chain.clone(), float64(*r.rtt), nil}
 }
 
 // Status succeeds if response contains given status code.
@@ -178,12 +209,19 @@ func (r *Response) StatusRange(rn StatusRange) *Response {
 
 	if actual == "" || actual != expected {
 		if actual == "" {
-			r.chain.fail("\nexpected status from range:\n %q\n\nbut got:\n %q",
-				expected, status)
+			r.chain.failWith(failure{
+				assertType: "response",
+				assertion:  "expected status from range",
+				expected:   fmt.Sprintf(" %q", expected),
+				actual:     fmt.Sprintf(" %q", status),
+			})
 		} else {
-			r.chain.fail(
-				"\nexpected status from range:\n %q\n\nbut got:\n %q (%q)",
-				expected, actual, status)
+			r.chain.failWith(failure{
+				assertType: "response",
+				assertion:  "expected status from range",
+				expected:   fmt.Sprintf(" %q", expected),
+				actual:     fmt.Sprintf(" %q (%q)", actual, status),
+			})
 		}
 	}
 
@@ -216,15 +254,66 @@ func statusRangeText(code int) string {
 
 // Headers returns a new Object that may be used to inspect header map.
 //
+// Header names are case-insensitive: keys of returned object are
+// canonicalized using textproto.CanonicalMIMEHeaderKey, and so are keys
+// passed to its methods like ContainsKey and Value, and top-level keys of
+// maps passed to its methods like Equal and ContainsMap.
+//
 // Example:
 //  resp := NewResponse(t, response)
 //  resp.Headers().Value("Content-Type").String().Equal("application-json")
+//  resp.Headers().ContainsKey("content-type")
 func (r *Response) Headers() *Object {
+	return r.headerObject(func() http.Header {
+		return r.resp.Header
+	})
+}
+
+// Trailers returns a new Object that may be used to inspect trailer map.
+//
+// Trailer names are case-insensitive, like in Headers.
+//
+// Trailers are sent by server after response body. Response body is fully
+// read when Response is created, so trailers are already available here.
+// If server didn't send trailers, the map is empty.
+//
+// Example:
+//  resp := NewResponse(t, response)
+//  resp.Trailers().Value("Grpc-Status").Array().Elements("0")
+func (r *Response) Trailers() *Object {
+	return r.headerObject(func() http.Header {
+		return r.resp.Trailer
+	})
+}
+
+func (r *Response) headerObject(getHeader func() http.Header) *Object {
+	chain := r.chain.clone()
+	chain.headerKeys = true
+
 	var value map[string]interface{}
+	if !chain.failed() {
+		canonHeader := http.Header{}
+		for k, v := range getHeader() {
+			key := textproto.CanonicalMIMEHeaderKey(k)
+			canonHeader[key] = append(canonHeader[key], v...)
+		}
+		value, _ = canonMap(&chain, canonHeader)
+	}
+	return &Object{chain, value}
+}
+
+// Trailer returns a new String object that may be used to inspect given
+// trailer. See Trailers.
+//
+// Example:
+//  resp := NewResponse(t, response)
+//  resp.Trailer("Grpc-Status").Equal("0")
+func (r *Response) Trailer(trailer string) *String {
+	value := ""
 	if !r.chain.failed() {
-		value, _ = canonMap(&r.chain, r.resp.Header)
+		value = r.resp.Trailer.Get(trailer)
 	}
-	return &Object{r.chain, value}
+	return &String{r.chain.clone(), value}
 }
 
 // Header returns a new String object that may be used to inspect given header.
@@ -238,7 +327,57 @@ func (r *Response) Header(header string) *String {
 	if !r.chain.failed() {
 		value = r.resp.Header.Get(header)
 	}
-	return &String{r.chain, value}
+	return &String{r.chain.clone(), value}
+}
+
+// RetryAfter returns a new Duration object that may be used to inspect
+// the delay advertised by "Retry-After" header.
+//
+// Both header forms are supported: delay in seconds (e.g. "120") and
+// HTTP-date (e.g. "Fri, 31 Dec 1999 23:59:59 GMT"). For the latter,
+// the duration is computed relative to the current time, and dates in
+// the past are treated as zero delay.
+//
+// If header is missing or can't be parsed, RetryAfter reports failure and
+// returns unset (but non-nil) Duration.
+//
+// Example:
+//  resp := NewResponse(t, response)
+//  resp.RetryAfter().InRange(time.Second, time.Minute)
+func (r *Response) RetryAfter() *Duration {
+	if r.chain.failed() {
+		return &Duration{r.chain.clone(), nil}
+	}
+
+	header := strings.TrimSpace(r.resp.Header.Get("Retry-After"))
+	if header == "" {
+		r.chain.failWith(failure{
+			assertType: "response",
+			assertion:  "expected response with \"Retry-After\" header",
+			details:    ", but got none",
+		})
+		return &Duration{r.chain.clone(), nil}
+	}
+
+	var d time.Duration
+
+	if secs, err := strconv.ParseUint(header, 10, 32); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		if d = time.Until(date); d < 0 {
+			d = 0
+		}
+	} else {
+		r.chain.failWith(failure{
+			assertType: "response",
+			assertion: "expected \"Retry-After\" header with delay in seconds" +
+				" or HTTP-date",
+			actual: fmt.Sprintf(" %q", header),
+		})
+		return &Duration{r.chain.clone(), nil}
+	}
+
+	return &Duration{r.chain.clone(), &d}
 }
 
 // Cookies returns a new Array object with all cookie names set by this response.
@@ -253,13 +392,13 @@ func (r *Response) Header(header string) *String {
 //  resp.Cookies().Contains("session")
 func (r *Response) Cookies() *Array {
 	if r.chain.failed() {
-		return &Array{r.chain, nil}
+		return &Array{r.chain.clone(), nil}
 	}
 	names := []interface{}{}
 	for _, c := range r.cookies {
 		names = append(names, c.Name)
 	}
-	return &Array{r.chain, names}
+	return &Array{r.chain.clone(), names}
 }
 
 // Cookie returns a new Cookie object that may be used to inspect given cookie
@@ -274,18 +413,23 @@ func (r *Response) Cookies() *Array {
 //  resp.Cookie("session").Domain().Equal("example.com")
 func (r *Response) Cookie(name string) *Cookie {
 	if r.chain.failed() {
-		return &Cookie{r.chain, nil}
+		return &Cookie{r.chain.clone(), nil}
 	}
 	names := []string{}
 	for _, c := range r.cookies {
 		if c.Name == name {
-			return &Cookie{r.chain, c}
+			return &Cookie{r.chain.clone(), c}
 		}
 		names = append(names, c.Name)
 	}
-	r.chain.fail("\nexpected response with cookie:\n %q\n\nbut got only cookies:\n%s",
-		name, dumpValue(names))
-	return &Cookie{r.chain, nil}
+	r.chain.failWith(failure{
+		assertType: "response",
+		assertion:  "expected response with cookie",
+		expected:   fmt.Sprintf(" %q", name),
+		got:        "but got only cookies",
+		actual:     dumpValue(names),
+	})
+	return &Cookie{r.chain.clone(), nil}
 }
 
 // Websocket returns Websocket object that can be used to interact with
@@ -303,7 +447,7 @@ func (r *Response) Websocket() *Websocket {
 	if !r.chain.failed() && r.websocket == nil {
 		r.chain.fail("\nunexpected Websocket call for non-WebSocket response")
 	}
-	return makeWebsocket(r.config, r.chain, r.websocket)
+	return makeWebsocket(r.config, r.chain.clone(), r.websocket)
 }
 
 // Body returns a new String object that may be used to inspect response body.
@@ -313,7 +457,7 @@ func (r *Response) Websocket() *Websocket {
 //  resp.Body().NotEmpty()
 //  resp.Body().Length().Equal(100)
 func (r *Response) Body() *String {
-	return &String{r.chain, string(r.content)}
+	return &String{r.chain.clone(), string(r.content)}
 }
 
 // NoContent succeeds if response contains empty Content-Type header and
@@ -334,6 +478,9 @@ func (r *Response) NoContent() *Response {
 // ContentType succeeds if response contains Content-Type header with given
 // media type and charset.
 //
+// Content-Type header is parsed using mime.ParseMediaType. Media type and
+// charset are compared case-insensitively, and other parameters are ignored.
+//
 // If charset is omitted, and mediaType is non-empty, Content-Type header
 // should contain empty or utf-8 charset.
 //
@@ -390,7 +537,7 @@ func (r *Response) Text(opts ...ContentOpts) *String {
 		content = string(r.content)
 	}
 
-	return &String{r.chain, content}
+	return &String{r.chain.clone(), content}
 }
 
 // Form returns a new Object that may be used to inspect form contents
@@ -408,7 +555,7 @@ func (r *Response) Text(opts ...ContentOpts) *String {
 //  }).Value("foo").Equal("bar")
 func (r *Response) Form(opts ...ContentOpts) *Object {
 	object := r.getForm(opts...)
-	return &Object{r.chain, object}
+	return &Object{r.chain.clone(), object}
 }
 
 func (r *Response) getForm(opts ...ContentOpts) map[string]interface{} {
@@ -437,15 +584,25 @@ func (r *Response) getForm(opts ...ContentOpts) map[string]interface{} {
 // JSON succeeds if response contains "application/json" Content-Type header
 // with empty or "utf-8" charset and if JSON may be decoded from response body.
 //
+// Content-Type is checked before decoding body, so if server returns e.g. an
+// HTML error page, failure reports unexpected media type instead of a JSON
+// syntax error. If server is known to use a different (or wrong) media type
+// for JSON, it may be overridden using ContentOpts.
+//
+// Body is decoded using Config.JSONDecoder, or encoding/json if it's not set.
+//
 // Example:
 //  resp := NewResponse(t, response)
 //  resp.JSON().Array().Elements("foo", "bar")
 //  resp.JSON(ContentOpts{
 //    MediaType: "application/json",
 //  }).Array.Elements("foo", "bar")
+//  resp.JSON(ContentOpts{
+//    MediaType: "text/plain",
+//  }).Array.Elements("foo", "bar")
 func (r *Response) JSON(opts ...ContentOpts) *Value {
 	value := r.getJSON(opts...)
-	return &Value{r.chain, value}
+	return &Value{r.chain.clone(), value}
 }
 
 func (r *Response) getJSON(opts ...ContentOpts) interface{} {
@@ -457,11 +614,7 @@ func (r *Response) getJSON(opts ...ContentOpts) interface{} {
 		return nil
 	}
 
-	var value interface{}
-	if err := json.Unmarshal(r.content, &value); err != nil {
-		r.chain.fail(err.Error())
-		return nil
-	}
+	value, _ := decodeJSON(&r.chain, r.config.JSONDecoder, r.content)
 
 	return value
 }
@@ -469,14 +622,18 @@ func (r *Response) getJSON(opts ...ContentOpts) interface{} {
 // JSONP returns a new Value object that may be used to inspect JSONP contents
 // of response.
 //
-// JSONP succeeds if response contains "application/javascript" Content-Type
-// header with empty or "utf-8" charset and response body of the following form:
+// JSONP succeeds if response contains JavaScript Content-Type header with empty
+// or "utf-8" charset and response body of the following form:
 //  callback(<valid json>);
 // or:
 //  callback(<valid json>)
 //
 // Whitespaces are allowed.
 //
+// The following media types are accepted: "application/javascript",
+// "text/javascript", and "application/x-javascript". If ContentOpts with
+// non-empty MediaType is given, only that media type is accepted.
+//
 // Example:
 //  resp := NewResponse(t, response)
 //  resp.JSONP("myCallback").Array().Elements("foo", "bar")
@@ -485,11 +642,17 @@ func (r *Response) getJSON(opts ...ContentOpts) interface{} {
 //  }).Array.Elements("foo", "bar")
 func (r *Response) JSONP(callback string, opts ...ContentOpts) *Value {
 	value := r.getJSONP(callback, opts...)
-	return &Value{r.chain, value}
+	return &Value{r.chain.clone(), value}
 }
 
 var (
 	jsonp = regexp.MustCompile(`^\s*([^\s(]+)\s*\((.*)\)\s*;*\s*$`)
+
+	jsonpTypes = []string{
+		"application/javascript",
+		"text/javascript",
+		"application/x-javascript",
+	}
 )
 
 func (r *Response) getJSONP(callback string, opts ...ContentOpts) interface{} {
@@ -497,24 +660,32 @@ func (r *Response) getJSONP(callback string, opts ...ContentOpts) interface{} {
 		return nil
 	}
 
-	if !r.checkContentOpts(opts, "application/javascript") {
+	expectedType := jsonpTypes[0]
+	if mediaType, _, err := mime.ParseMediaType(
+		r.resp.Header.Get("Content-Type")); err == nil {
+		for _, t := range jsonpTypes {
+			if mediaType == t {
+				expectedType = mediaType
+			}
+		}
+	}
+
+	if !r.checkContentOpts(opts, expectedType) {
 		return nil
 	}
 
 	m := jsonp.FindSubmatch(r.content)
 	if len(m) != 3 || string(m[1]) != callback {
-		r.chain.fail(
-			"\nexpected JSONP body in form of:\n \"%s(<valid json>)\"\n\nbut got:\n %q\n",
-			callback,
-			string(r.content))
+		r.chain.failWith(failure{
+			assertType: "response",
+			assertion:  "expected JSONP body in form of",
+			expected:   fmt.Sprintf(" \"%s(<valid json>)\"", callback),
+			actual:     fmt.Sprintf(" %q", string(r.content)),
+		})
 		return nil
 	}
 
-	var value interface{}
-	if err := json.Unmarshal(m[2], &value); err != nil {
-		r.chain.fail(err.Error())
-		return nil
-	}
+	value, _ := decodeJSON(&r.chain, r.config.JSONDecoder, m[2])
 
 	return value
 }
@@ -548,14 +719,21 @@ func (r *Response) checkContentType(expectedType string, expectedCharset ...stri
 
 	mediaType, params, err := mime.ParseMediaType(contentType)
 	if err != nil {
-		r.chain.fail("\ngot invalid \"Content-Type\" header %q", contentType)
+		r.chain.failWith(failure{
+			assertType: "response",
+			assertion:  "expected valid \"Content-Type\" header",
+			actual:     fmt.Sprintf(" %q", contentType),
+		})
 		return false
 	}
 
-	if mediaType != expectedType {
-		r.chain.fail(
-			"\nexpected \"Content-Type\" header with %q media type,"+
-				"\nbut got %q", expectedType, mediaType)
+	if !strings.EqualFold(mediaType, expectedType) {
+		r.chain.failWith(failure{
+			assertType: "response",
+			assertion: fmt.Sprintf(
+				"expected \"Content-Type\" header with %q media type", expectedType),
+			actual: fmt.Sprintf(" %q", mediaType),
+		})
 		return false
 	}
 
@@ -563,16 +741,23 @@ func (r *Response) checkContentType(expectedType string, expectedCharset ...stri
 
 	if len(expectedCharset) == 0 {
 		if charset != "" && !strings.EqualFold(charset, "utf-8") {
-			r.chain.fail(
-				"\nexpected \"Content-Type\" header with \"utf-8\" or empty charset,"+
-					"\nbut got %q", charset)
+			r.chain.failWith(failure{
+				assertType: "response",
+				assertion: "expected \"Content-Type\" header with \"utf-8\"" +
+					" or empty charset",
+				actual: fmt.Sprintf(" %q", charset),
+			})
 			return false
 		}
 	} else {
 		if !strings.EqualFold(charset, expectedCharset[0]) {
-			r.chain.fail(
-				"\nexpected \"Content-Type\" header with %q charset,"+
-					"\nbut got %q", expectedCharset[0], charset)
+			r.chain.failWith(failure{
+				assertType: "response",
+				assertion: fmt.Sprintf(
+					"expected \"Content-Type\" header with %q charset",
+					expectedCharset[0]),
+				actual: fmt.Sprintf(" %q", charset),
+			})
 			return false
 		}
 	}
@@ -582,7 +767,11 @@ func (r *Response) checkContentType(expectedType string, expectedCharset ...stri
 
 func (r *Response) checkEqual(what string, expected, actual interface{}) {
 	if !reflect.DeepEqual(expected, actual) {
-		r.chain.fail("\nexpected %s equal to:\n%s\n\nbut got:\n%s", what,
-			dumpValue(expected), dumpValue(actual))
+		r.chain.failWith(failure{
+			assertType: "response",
+			assertion:  fmt.Sprintf("expected %s equal to", what),
+			expected:   dumpValue(expected),
+			actual:     dumpValue(actual),
+		})
 	}
 }
@@ -2,8 +2,11 @@ package httpexpect
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -21,6 +24,7 @@ func TestResponseFailed(t *testing.T) {
 	resp.chain.assertFailed(t)
 
 	assert.False(t, resp.Duration() == nil)
+	assert.False(t, resp.Attempts() == nil)
 	assert.False(t, resp.Headers() == nil)
 	assert.False(t, resp.Header("foo") == nil)
 	assert.False(t, resp.Cookies() == nil)
@@ -29,8 +33,12 @@ func TestResponseFailed(t *testing.T) {
 	assert.False(t, resp.JSON() == nil)
 	assert.False(t, resp.JSONP("") == nil)
 
+	resp.Attempts().chain.assertFailed(t)
 	resp.Headers().chain.assertFailed(t)
 	resp.Header("foo").chain.assertFailed(t)
+	resp.Trailers().chain.assertFailed(t)
+	resp.Trailer("foo").chain.assertFailed(t)
+	resp.RetryAfter().chain.assertFailed(t)
 	resp.Cookies().chain.assertFailed(t)
 	resp.Cookie("foo").chain.assertFailed(t)
 	resp.Body().chain.assertFailed(t)
@@ -158,6 +166,194 @@ func TestResponseStatusRange(t *testing.T) {
 	}
 }
 
+type closeTrackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestResponseHeadersCaseInsensitive(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+			"x-custom":     {"foo"},
+		},
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	headers := resp.Headers()
+
+	for _, key := range []string{"Content-Type", "content-type", "CONTENT-TYPE"} {
+		headers.ContainsKey(key)
+		headers.chain.assertOK(t)
+
+		headers.Value(key).Array().Elements("application/json")
+		headers.chain.assertOK(t)
+
+		headers.ValueEqual(key, []string{"application/json"})
+		headers.chain.assertOK(t)
+	}
+
+	headers.ContainsKey("X-Custom")
+	headers.chain.assertOK(t)
+
+	headers.ContainsKey("x-custom")
+	headers.chain.assertOK(t)
+
+	headers.KeysEqual("content-type", "x-custom")
+	headers.chain.assertOK(t)
+
+	headers.NotContainsKey("content-length")
+	headers.chain.assertOK(t)
+
+	headers.NotContainsKey("content-type")
+	headers.chain.assertFailed(t)
+	headers.chain.reset()
+
+	headers.ContainsMap(map[string]interface{}{
+		"content-type": []string{"application/json"},
+	})
+	headers.chain.assertOK(t)
+
+	headers.NotContainsMap(map[string]interface{}{
+		"content-type": []string{"application/json"},
+	})
+	headers.chain.assertFailed(t)
+	headers.chain.reset()
+
+	headers.ContainsMapFunc(map[string]interface{}{
+		"CONTENT-TYPE": []string{"application/json"},
+	})
+	headers.chain.assertOK(t)
+
+	headers.Equal(map[string]interface{}{
+		"content-type": []string{"application/json"},
+		"x-custom":     []string{"foo"},
+	})
+	headers.chain.assertOK(t)
+
+	headers.NotEqual(map[string]interface{}{
+		"content-type": []string{"application/json"},
+		"x-custom":     []string{"foo"},
+	})
+	headers.chain.assertFailed(t)
+	headers.chain.reset()
+
+	assert.Equal(t, map[string]interface{}{
+		"Content-Type": []interface{}{"application/json"},
+		"X-Custom":     []interface{}{"foo"},
+	}, headers.Raw())
+
+	resp.Header("content-type").Equal("application/json")
+	resp.chain.assertOK(t)
+
+	object := NewObject(reporter, map[string]interface{}{"Content-Type": "foo"})
+	object.ContainsKey("content-type")
+	object.chain.assertFailed(t)
+}
+
+func TestResponseTrailers(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	trailers := map[string][]string{
+		"Grpc-Status":  {"0"},
+		"Grpc-Message": {"ok"},
+	}
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Trailer:    http.Header(trailers),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.Trailers().Equal(trailers)
+	resp.chain.assertOK(t)
+
+	resp.Trailers().ContainsKey("Grpc-Status")
+	resp.chain.assertOK(t)
+
+	resp.Trailer("Grpc-Status").Equal("0")
+	resp.chain.assertOK(t)
+
+	resp.Trailer("grpc-message").Equal("ok")
+	resp.chain.assertOK(t)
+
+	resp.Trailer("Missing").Empty()
+	resp.chain.assertOK(t)
+
+	empty := NewResponse(reporter, &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	})
+
+	empty.Trailers().Empty()
+	empty.chain.assertOK(t)
+
+	empty.Trailer("Grpc-Status").Empty()
+	empty.chain.assertOK(t)
+}
+
+func TestResponseTrailersServer(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Trailer", "X-Checksum")
+		_, _ = w.Write([]byte("body"))
+		w.Header().Set("X-Checksum", "abc")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	resp := e.GET("/").Expect()
+
+	resp.Body().Equal("body")
+	resp.Trailer("X-Checksum").Equal("abc")
+	resp.Trailers().Value("X-Checksum").Array().Elements("abc")
+}
+
+func TestResponseRaw(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	body := &closeTrackingBody{Reader: bytes.NewBufferString("body")}
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       body,
+	}
+
+	resp := NewResponse(reporter, httpResp)
+	resp.chain.assertOK(t)
+
+	assert.True(t, body.closed)
+
+	for i := 0; i < 2; i++ {
+		raw := resp.Raw()
+		assert.Equal(t, httpResp, raw)
+
+		b, err := ioutil.ReadAll(raw.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "body", string(b))
+		assert.NoError(t, raw.Body.Close())
+	}
+
+	resp.Body().Equal("body")
+	resp.chain.assertOK(t)
+}
+
 func TestResponseHeaders(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -197,6 +393,77 @@ func TestResponseHeaders(t *testing.T) {
 	resp.Header("Bad-Header").Empty().chain.assertOK(t)
 }
 
+func TestResponseRetryAfter(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	makeResp := func(value ...string) *Response {
+		header := http.Header{}
+		for _, v := range value {
+			header.Add("Retry-After", v)
+		}
+		return NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     header,
+		})
+	}
+
+	t.Run("seconds", func(t *testing.T) {
+		resp := makeResp("120")
+
+		d := resp.RetryAfter()
+		d.chain.assertOK(t)
+		d.IsSet().Equal(2 * time.Minute)
+		d.chain.assertOK(t)
+
+		resp = makeResp("0")
+
+		d = resp.RetryAfter()
+		d.chain.assertOK(t)
+		d.IsSet().Equal(0)
+		d.chain.assertOK(t)
+	})
+
+	t.Run("date", func(t *testing.T) {
+		date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+		resp := makeResp(date)
+
+		d := resp.RetryAfter()
+		d.chain.assertOK(t)
+		d.IsSet().InRange(time.Hour-time.Minute, time.Hour)
+		d.chain.assertOK(t)
+
+		resp = makeResp("Fri, 31 Dec 1999 23:59:59 GMT")
+
+		d = resp.RetryAfter()
+		d.chain.assertOK(t)
+		d.IsSet().Equal(0)
+		d.chain.assertOK(t)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		resp := makeResp()
+
+		d := resp.RetryAfter()
+		d.chain.assertFailed(t)
+		d.chain.reset()
+		d.NotSet()
+		d.chain.assertOK(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, v := range []string{"-1", "1.5", "soon", "2020-01-01T00:00:00Z"} {
+			resp := makeResp(v)
+
+			d := resp.RetryAfter()
+			d.chain.assertFailed(t)
+			d.chain.reset()
+			d.NotSet()
+			d.chain.assertOK(t)
+		}
+	})
+}
+
 func TestResponseCookies(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -434,6 +701,38 @@ func TestResponseContentType(t *testing.T) {
 	resp.chain.reset()
 }
 
+func TestResponseContentTypeCase(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"Application/JSON; Charset=UTF-8; boundary=foo"},
+	}
+
+	resp := NewResponse(reporter, &http.Response{
+		Header: http.Header(headers),
+	})
+
+	resp.ContentType("application/json")
+	resp.chain.assertOK(t)
+	resp.chain.reset()
+
+	resp.ContentType("APPLICATION/JSON", "utf-8")
+	resp.chain.assertOK(t)
+	resp.chain.reset()
+
+	resp.ContentType("application/xml")
+	resp.chain.assertFailed(t)
+	resp.chain.reset()
+
+	assert.Contains(t, reporter.message, "media type")
+
+	resp.ContentType("application/json", "ascii")
+	resp.chain.assertFailed(t)
+	resp.chain.reset()
+
+	assert.Contains(t, reporter.message, "charset")
+}
+
 func TestResponseContentTypeEmptyCharset(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -716,6 +1015,124 @@ func TestResponseJSON(t *testing.T) {
 		map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
 }
 
+func TestResponseJSONPreserveNumbers(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/json"},
+	}
+
+	body := `{"id": 9007199254740993, "count": 2, "ids": [9007199254740993, 1]}`
+
+	newResp := func(preserveNumbers bool) *Response {
+		chain := makeChain(reporter)
+		chain.preserveNumbers = preserveNumbers
+
+		return makeResponse(responseOpts{
+			chain: chain,
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header(headers),
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			},
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		object := newResp(false).JSON().Object()
+
+		object.Value("id").Number().Equal(int64(9007199254740992))
+		object.chain.assertOK(t)
+
+		object.ValueEqual("id", int64(9007199254740992))
+		object.chain.assertOK(t)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		object := newResp(true).JSON().Object()
+
+		number := object.Value("id").Number()
+		number.Equal(int64(9007199254740993))
+		number.chain.assertOK(t)
+		number.chain.reset()
+
+		number.Equal(int64(9007199254740992))
+		number.chain.assertFailed(t)
+		number.chain.reset()
+
+		object.ValueEqual("id", int64(9007199254740993))
+		object.chain.assertOK(t)
+		object.chain.reset()
+
+		object.ValueEqual("id", int64(9007199254740992))
+		object.chain.assertFailed(t)
+		object.chain.reset()
+
+		object.ValueEqual("count", 2)
+		object.chain.assertOK(t)
+		object.chain.reset()
+
+		object.ValueType("id", "number")
+		object.chain.assertOK(t)
+		object.chain.reset()
+
+		array := object.Value("ids").Array()
+		array.Elements(uint64(9007199254740993), 1)
+		array.chain.assertOK(t)
+		array.chain.reset()
+
+		array.IsOrderedReverse()
+		array.chain.assertOK(t)
+		array.chain.reset()
+	})
+}
+
+func TestResponseJSONDecoder(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/json"},
+	}
+
+	body := `{"key": 123}`
+
+	newResp := func(decoder JSONDecoder) *Response {
+		return makeResponse(responseOpts{
+			config: Config{
+				JSONDecoder: decoder,
+			},
+			chain: makeChain(reporter),
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header(headers),
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			},
+		})
+	}
+
+	decoder := &mockJSONDecoder{}
+
+	resp := newResp(decoder)
+	resp.JSON().Object().ValueEqual("key", 123)
+	resp.chain.assertOK(t)
+
+	assert.Equal(t, 1, decoder.calls)
+	assert.Equal(t,
+		map[string]interface{}{"key": 123.0}, resp.JSON().Object().Raw())
+
+	decoder = &mockJSONDecoder{err: errors.New("decode error")}
+
+	resp = newResp(decoder)
+	resp.JSON()
+	resp.chain.assertFailed(t)
+
+	assert.Equal(t, 1, decoder.calls)
+
+	resp = newResp(nil)
+	resp.JSON().Object().ValueEqual("key", 123)
+	resp.chain.assertOK(t)
+}
+
 func TestResponseJSONBadBody(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -789,6 +1206,70 @@ func TestResponseJSONCharsetBad(t *testing.T) {
 	assert.Equal(t, nil, resp.JSON().Raw())
 }
 
+func TestResponseJSONContentTypeBad(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"text/html; charset=utf-8"},
+	}
+
+	body := `<html><body>Internal Server Error</body></html>`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.JSON()
+	resp.chain.assertFailed(t)
+	resp.chain.reset()
+
+	assert.Contains(t, reporter.message, `"application/json"`)
+	assert.Contains(t, reporter.message, `"text/html"`)
+
+	assert.Equal(t, nil, resp.JSON().Raw())
+	resp.chain.reset()
+
+	resp.JSON(ContentOpts{MediaType: "text/html"})
+	resp.chain.assertFailed(t)
+	resp.chain.reset()
+
+	assert.Contains(t, reporter.message, "invalid character")
+}
+
+func TestResponseJSONContentTypeOverride(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"text/plain"},
+	}
+
+	body := `{"key": "value"}`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.JSON()
+	resp.chain.assertFailed(t)
+	resp.chain.reset()
+
+	resp.JSON(ContentOpts{MediaType: "text/plain"})
+	resp.chain.assertOK(t)
+	resp.chain.reset()
+
+	assert.Equal(t,
+		map[string]interface{}{"key": "value"},
+		resp.JSON(ContentOpts{MediaType: "text/plain"}).Object().Raw())
+}
+
 func TestResponseJSONP(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -842,6 +1323,67 @@ func TestResponseJSONP(t *testing.T) {
 	}
 }
 
+func TestResponseJSONPContentTypes(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		contentType string
+		ok          bool
+	}{
+		{"application/javascript", true},
+		{"text/javascript; charset=utf-8", true},
+		{"application/x-javascript", true},
+		{"application/json", false},
+		{"text/html", false},
+		{"text/javascript; charset=bad", false},
+		{"", false},
+	}
+
+	for _, tc := range cases {
+		headers := map[string][]string{
+			"Content-Type": {tc.contentType},
+		}
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`foo({"key": "value"})`)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		value := resp.JSONP("foo")
+		if tc.ok {
+			resp.chain.assertOK(t)
+			assert.Equal(t,
+				map[string]interface{}{"key": "value"}, value.Object().Raw())
+		} else {
+			resp.chain.assertFailed(t)
+		}
+		resp.chain.reset()
+	}
+
+	headers := map[string][]string{
+		"Content-Type": {"text/javascript"},
+	}
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`foo({"key": "value"})`)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.JSONP("foo", ContentOpts{MediaType: "text/javascript"})
+	resp.chain.assertOK(t)
+	resp.chain.reset()
+
+	resp.JSONP("foo", ContentOpts{MediaType: "application/javascript"})
+	resp.chain.assertFailed(t)
+	resp.chain.reset()
+}
+
 func TestResponseJSONPBadBody(t *testing.T) {
 	reporter := newMockReporter(t)
 
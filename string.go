@@ -1,10 +1,14 @@
 package httpexpect
 
 import (
+	"encoding/base64"
+	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // String provides methods to inspect attached string value
@@ -42,26 +46,87 @@ func (s *String) Path(path string) *Value {
 
 // Schema is similar to Value.Schema.
 func (s *String) Schema(schema interface{}) *String {
-	checkSchema(&s.chain, s.value, schema)
+	checkSchema(&s.chain, "string", s.value, schema)
 	return s
 }
 
 // Length returns a new Number object that may be used to inspect string length.
 //
+// Length is the number of Unicode code points (runes) in the string, not the
+// number of bytes. Use ByteLength to inspect length in bytes.
+//
 // Example:
-//  str := NewString(t, "Hello")
-//  str.Length().Equal(5)
+//  str := NewString(t, "Привет")
+//  str.Length().Equal(6)
 func (s *String) Length() *Number {
-	return &Number{s.chain, float64(len(s.value))}
+	return &Number{s.chain.clone(), float64(utf8.RuneCountInString(s.value)), nil}
+}
+
+// ByteLength returns a new Number object that may be used to inspect string
+// length in bytes.
+//
+// Example:
+//  str := NewString(t, "Привет")
+//  str.ByteLength().Equal(12)
+func (s *String) ByteLength() *Number {
+	return &Number{s.chain.clone(), float64(len(s.value)), nil}
+}
+
+// LengthMin succeeds if string length (in runes, like in Length) is greater
+// than or equal to n.
+//
+// Example:
+//  str := NewString(t, "Hello")
+//  str.LengthMin(5)
+func (s *String) LengthMin(n int) *String {
+	length := utf8.RuneCountInString(s.value)
+	if length < n {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string with length at least",
+			expected:   fmt.Sprintf(" %d", n),
+			got:        "but got length",
+			actual:     fmt.Sprintf(" %d", length),
+			details:    fmt.Sprintf("\n\nstring:\n %q", s.value),
+		})
+	}
+	return s
+}
+
+// LengthMax succeeds if string length (in runes, like in Length) is less
+// than or equal to n.
+//
+// Example:
+//  str := NewString(t, "Hello")
+//  str.LengthMax(5)
+func (s *String) LengthMax(n int) *String {
+	length := utf8.RuneCountInString(s.value)
+	if length > n {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string with length at most",
+			expected:   fmt.Sprintf(" %d", n),
+			got:        "but got length",
+			actual:     fmt.Sprintf(" %d", length),
+			details:    fmt.Sprintf("\n\nstring:\n %q", s.value),
+		})
+	}
+	return s
 }
 
 // DateTime parses date/time from string an returns a new DateTime object.
 //
 // If layout is given, DateTime() uses time.Parse() with given layout.
-// Otherwise, it uses http.ParseTime(). If pasing error occurred,
-// DateTime reports failure and returns empty (but non-nil) object.
+// Otherwise, it first tries RFC 3339 layout (optionally with fractional
+// seconds), commonly used in JSON APIs, and then falls back to
+// http.ParseTime(), which accepts RFC 1123 and other HTTP date formats.
+// If pasing error occurred, DateTime reports failure and returns empty
+// (but non-nil) object.
 //
 // Example:
+//   str := NewString(t, "1994-11-15T08:12:31Z")
+//   str.DateTime().Lt(time.Now())
+//
 //   str := NewString(t, "Tue, 15 Nov 1994 08:12:31 GMT")
 //   str.DateTime().Lt(time.Now())
 //
@@ -69,7 +134,7 @@ func (s *String) Length() *Number {
 //   str.DateTime(time.RFC822).Lt(time.Now())
 func (s *String) DateTime(layout ...string) *DateTime {
 	if s.chain.failed() {
-		return &DateTime{s.chain, time.Unix(0, 0)}
+		return &DateTime{s.chain.clone(), time.Unix(0, 0)}
 	}
 	var (
 		t   time.Time
@@ -77,14 +142,265 @@ func (s *String) DateTime(layout ...string) *DateTime {
 	)
 	if len(layout) != 0 {
 		t, err = time.Parse(layout[0], s.value)
-	} else {
+	} else if t, err = time.Parse(time.RFC3339Nano, s.value); err != nil {
 		t, err = http.ParseTime(s.value)
 	}
 	if err != nil {
 		s.chain.fail(err.Error())
-		return &DateTime{s.chain, time.Unix(0, 0)}
+		return &DateTime{s.chain.clone(), time.Unix(0, 0)}
+	}
+	return &DateTime{s.chain.clone(), t}
+}
+
+// AsBoolean parses boolean from string and returns a new Boolean object.
+//
+// The following literals are accepted:
+//  - true:  "true", "True", "TRUE", "t", "T", "1", "yes", "Yes", "YES"
+//  - false: "false", "False", "FALSE", "f", "F", "0", "no", "No", "NO"
+//
+// Any other string (including mixed case like "tRUE" and strings with
+// surrounding spaces) is rejected. If parsing error occurred, AsBoolean
+// reports failure and returns empty (but non-nil) object, which holds false.
+//
+// Example:
+//   str := NewString(t, "true")
+//   str.AsBoolean().True()
+//
+//   str := NewString(t, "no")
+//   str.AsBoolean().False()
+func (s *String) AsBoolean() *Boolean {
+	if s.chain.failed() {
+		return &Boolean{s.chain.clone(), false}
+	}
+	switch s.value {
+	case "yes", "Yes", "YES":
+		return &Boolean{s.chain.clone(), true}
+	case "no", "No", "NO":
+		return &Boolean{s.chain.clone(), false}
+	}
+	b, err := strconv.ParseBool(s.value)
+	if err != nil {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string convertible to boolean",
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
+		return &Boolean{s.chain.clone(), false}
+	}
+	return &Boolean{s.chain.clone(), b}
+}
+
+// AsNumber parses number from string and returns a new Number object.
+//
+// If base is omitted, AsNumber uses strconv.ParseFloat() and accepts
+// floating point numbers like "3.14" or "1e5". If base is given, AsNumber
+// uses strconv.ParseInt() with that base and accepts only integers.
+// If parsing error occurred, AsNumber reports failure and returns empty
+// (but non-nil) object.
+//
+// Example:
+//   str := NewString(t, "100")
+//   str.AsNumber().Equal(100)
+//
+//   str := NewString(t, "ff")
+//   str.AsNumber(16).Equal(255)
+func (s *String) AsNumber(base ...int) *Number {
+	if s.chain.failed() {
+		return &Number{s.chain.clone(), 0, nil}
+	}
+
+	if len(base) > 1 {
+		s.chain.fail("\nunexpected multiple base arguments passed to AsNumber")
+		return &Number{s.chain.clone(), 0, nil}
+	}
+
+	if len(base) == 0 {
+		num, err := strconv.ParseFloat(s.value, 64)
+		if err != nil {
+			s.chain.failWith(failure{
+				assertType: "string",
+				assertion:  "expected string convertible to number",
+				actual:     fmt.Sprintf(" %q", s.value),
+			})
+			return &Number{s.chain.clone(), 0, nil}
+		}
+		return &Number{s.chain.clone(), num, nil}
+	}
+
+	num, err := strconv.ParseInt(s.value, base[0], 64)
+	if err != nil {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion: fmt.Sprintf(
+				"expected string convertible to integer with base %d", base[0]),
+			actual: fmt.Sprintf(" %q", s.value),
+		})
+		return &Number{s.chain.clone(), 0, nil}
+	}
+	return &Number{s.chain.clone(), float64(num), nil}
+}
+
+// Base64Decode decodes string using standard base64 encoding (RFC 4648,
+// with padding) and returns a new String object with decoded data.
+//
+// If string is not valid base64, Base64Decode reports failure and returns
+// empty (but non-nil) object.
+//
+// Example:
+//  str := NewString(t, "SGVsbG8=")
+//  str.Base64Decode().Equal("Hello")
+func (s *String) Base64Decode() *String {
+	return s.base64Decode(base64.StdEncoding)
+}
+
+// Base64DecodeRaw decodes string using URL-safe base64 encoding without
+// padding (RFC 4648, section 5), which is used e.g. in JWT, and returns a
+// new String object with decoded data.
+//
+// If string is not valid base64, Base64DecodeRaw reports failure and returns
+// empty (but non-nil) object.
+//
+// Example:
+//  str := NewString(t, "eyJmb28iOiJiYXIifQ")
+//  str.Base64DecodeRaw().Equal(`{"foo":"bar"}`)
+func (s *String) Base64DecodeRaw() *String {
+	return s.base64Decode(base64.RawURLEncoding)
+}
+
+func (s *String) base64Decode(enc *base64.Encoding) *String {
+	if s.chain.failed() {
+		return &String{s.chain.clone(), ""}
+	}
+	b, err := enc.DecodeString(s.value)
+	if err != nil {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected valid base64 string",
+			actual:     fmt.Sprintf(" %q", s.value),
+			details:    "\n\nerror:\n " + err.Error(),
+		})
+		return &String{s.chain.clone(), ""}
+	}
+	return &String{s.chain.clone(), string(b)}
+}
+
+// DecodeJSON decodes JSON document from string and returns a new Value
+// object that may be used to inspect it.
+//
+// This is useful when JSON document is serialized into a string field of
+// another JSON document. If string is not valid JSON, DecodeJSON reports
+// failure and returns empty (but non-nil) object.
+//
+// Numbers are decoded in the same way as in response body, so large integers
+// are preserved exactly if Config.PreserveNumbers is set.
+//
+// Example:
+//  str := NewString(t, `{"foo": 123}`)
+//  str.DecodeJSON().Object().ValueEqual("foo", 123)
+func (s *String) DecodeJSON() *Value {
+	if s.chain.failed() {
+		return &Value{s.chain.clone(), nil}
+	}
+	value, err := unmarshalCanon([]byte(s.value), s.chain.preserveNumbers)
+	if err != nil {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string containing valid JSON",
+			actual:     fmt.Sprintf(" %q", s.value),
+			details:    "\n\nerror:\n " + err.Error(),
+		})
+		return &Value{s.chain.clone(), nil}
+	}
+	return &Value{s.chain.clone(), value}
+}
+
+// Split slices string into all substrings separated by sep and returns
+// a new Array object with them.
+//
+// Split follows strings.Split semantics, so empty string produces array
+// with single empty element, and empty sep splits string after each
+// UTF-8 sequence.
+//
+// Example:
+//  str := NewString(t, "foo,bar,baz")
+//  str.Split(",").Elements("foo", "bar", "baz")
+func (s *String) Split(sep string) *Array {
+	if s.chain.failed() {
+		return &Array{s.chain.clone(), nil}
+	}
+	parts := []interface{}{}
+	for _, p := range strings.Split(s.value, sep) {
+		parts = append(parts, p)
+	}
+	return &Array{s.chain.clone(), parts}
+}
+
+// Trim returns a new String object with leading and trailing white space
+// removed, as defined by Unicode. Original object is not modified.
+//
+// Example:
+//  str := NewString(t, "  Hello \n")
+//  str.Trim().Equal("Hello")
+func (s *String) Trim() *String {
+	return &String{s.chain.clone(), strings.TrimSpace(s.value)}
+}
+
+// TrimPrefix returns a new String object without given leading prefix.
+// If string doesn't start with prefix, it's returned unchanged. Original
+// object is not modified.
+//
+// Example:
+//  str := NewString(t, "Bearer token")
+//  str.TrimPrefix("Bearer ").Equal("token")
+func (s *String) TrimPrefix(prefix string) *String {
+	return &String{s.chain.clone(), strings.TrimPrefix(s.value, prefix)}
+}
+
+// TrimSuffix returns a new String object without given trailing suffix.
+// If string doesn't end with suffix, it's returned unchanged. Original
+// object is not modified.
+//
+// Example:
+//  str := NewString(t, "image.png")
+//  str.TrimSuffix(".png").Equal("image")
+func (s *String) TrimSuffix(suffix string) *String {
+	return &String{s.chain.clone(), strings.TrimSuffix(s.value, suffix)}
+}
+
+// Unquote strips surrounding double quotes from string, unescapes its
+// contents using Go syntax (see strconv.Unquote), and returns a new String
+// object with the result. Original object is not modified.
+//
+// This is useful for quoted tokens like ETag header values. If string is
+// not properly double-quoted, Unquote reports failure and returns a new
+// String object with the original value.
+//
+// Example:
+//  str := NewString(t, `"abc\tdef"`)
+//  str.Unquote().Equal("abc\tdef")
+func (s *String) Unquote() *String {
+	if s.chain.failed() {
+		return &String{s.chain.clone(), s.value}
+	}
+	if !strings.HasPrefix(s.value, `"`) {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected double-quoted string",
+			actual:     " " + s.value,
+		})
+		return &String{s.chain.clone(), s.value}
+	}
+	value, err := strconv.Unquote(s.value)
+	if err != nil {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected valid double-quoted string",
+			actual:     " " + s.value,
+			details:    "\n\nerror:\n " + err.Error(),
+		})
+		return &String{s.chain.clone(), s.value}
 	}
-	return &DateTime{s.chain, t}
+	return &String{s.chain.clone(), value}
 }
 
 // Empty succeeds if string is empty.
@@ -112,8 +428,12 @@ func (s *String) NotEmpty() *String {
 //  str.Equal("Hello")
 func (s *String) Equal(value string) *String {
 	if !(s.value == value) {
-		s.chain.fail("\nexpected string equal to:\n %q\n\nbut got:\n %q",
-			value, s.value)
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string equal to",
+			expected:   fmt.Sprintf(" %q", value),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
 	}
 	return s
 }
@@ -125,11 +445,35 @@ func (s *String) Equal(value string) *String {
 //  str.NotEqual("Goodbye")
 func (s *String) NotEqual(value string) *String {
 	if !(s.value != value) {
-		s.chain.fail("\nexpected string not equal to:\n %q", value)
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string not equal to",
+			expected:   fmt.Sprintf(" %q", value),
+		})
 	}
 	return s
 }
 
+// EqualToAnyOf succeeds if string is equal to any of given Go strings.
+//
+// Example:
+//  str := NewString(t, "active")
+//  str.EqualToAnyOf("active", "pending")
+func (s *String) EqualToAnyOf(values ...string) *String {
+	for _, v := range values {
+		if s.value == v {
+			return s
+		}
+	}
+	s.chain.failWith(failure{
+		assertType: "string",
+		assertion:  "expected string equal to any of",
+		expected:   dumpValue(values),
+		actual:     fmt.Sprintf(" %q", s.value),
+	})
+	return s
+}
+
 // EqualFold succeeds if string is equal to given Go string after applying Unicode
 // case-folding (so it's a case-insensitive match).
 //
@@ -138,9 +482,12 @@ func (s *String) NotEqual(value string) *String {
 //  str.EqualFold("hELLo")
 func (s *String) EqualFold(value string) *String {
 	if !strings.EqualFold(s.value, value) {
-		s.chain.fail(
-			"\nexpected string equal to (case-insensitive):\n %q\n\nbut got:\n %q",
-			value, s.value)
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string equal to (case-insensitive)",
+			expected:   fmt.Sprintf(" %q", value),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
 	}
 	return s
 }
@@ -153,9 +500,12 @@ func (s *String) EqualFold(value string) *String {
 //  str.NotEqualFold("gOODBYe")
 func (s *String) NotEqualFold(value string) *String {
 	if strings.EqualFold(s.value, value) {
-		s.chain.fail(
-			"\nexpected string not equal to (case-insensitive):\n %q\n\nbut got:\n %q",
-			value, s.value)
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string not equal to (case-insensitive)",
+			expected:   fmt.Sprintf(" %q", value),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
 	}
 	return s
 }
@@ -167,9 +517,12 @@ func (s *String) NotEqualFold(value string) *String {
 //  str.Contains("ell")
 func (s *String) Contains(value string) *String {
 	if !strings.Contains(s.value, value) {
-		s.chain.fail(
-			"\nexpected string containing substring:\n %q\n\nbut got:\n %q",
-			value, s.value)
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string containing substring",
+			expected:   fmt.Sprintf(" %q", value),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
 	}
 	return s
 }
@@ -181,9 +534,12 @@ func (s *String) Contains(value string) *String {
 //  str.NotContains("bye")
 func (s *String) NotContains(value string) *String {
 	if strings.Contains(s.value, value) {
-		s.chain.fail(
-			"\nexpected string not containing substring:\n %q\n\nbut got:\n %q",
-			value, s.value)
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string not containing substring",
+			expected:   fmt.Sprintf(" %q", value),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
 	}
 	return s
 }
@@ -191,14 +547,22 @@ func (s *String) NotContains(value string) *String {
 // ContainsFold succeeds if string contains given Go string as a substring after
 // applying Unicode case-folding (so it's a case-insensitive match).
 //
+// Case-folding is the same as in strings.EqualFold: runes are compared using
+// simple Unicode folding, so e.g. "K" (Kelvin sign) matches "k", and "ſ"
+// (long s) matches "S". Foldings that change the number of runes, like "ß"
+// and "SS", are not supported.
+//
 // Example:
 //  str := NewString(t, "Hello")
 //  str.ContainsFold("ELL")
 func (s *String) ContainsFold(value string) *String {
-	if !strings.Contains(strings.ToLower(s.value), strings.ToLower(value)) {
-		s.chain.fail(
-			"\nexpected string containing substring (case-insensitive):\n %q"+
-				"\n\nbut got:\n %q", value, s.value)
+	if !containsFold(s.value, value) {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string containing substring (case-insensitive)",
+			expected:   fmt.Sprintf(" %q", value),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
 	}
 	return s
 }
@@ -206,14 +570,19 @@ func (s *String) ContainsFold(value string) *String {
 // NotContainsFold succeeds if string doesn't contain given Go string as a substring
 // after applying Unicode case-folding (so it's a case-insensitive match).
 //
+// Case-folding is the same as in ContainsFold.
+//
 // Example:
 //  str := NewString(t, "Hello")
 //  str.NotContainsFold("BYE")
 func (s *String) NotContainsFold(value string) *String {
-	if strings.Contains(strings.ToLower(s.value), strings.ToLower(value)) {
-		s.chain.fail(
-			"\nexpected string not containing substring (case-insensitive):\n %q"+
-				"\n\nbut got:\n %q", value, s.value)
+	if containsFold(s.value, value) {
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string not containing substring (case-insensitive)",
+			expected:   fmt.Sprintf(" %q", value),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
 	}
 	return s
 }
@@ -242,17 +611,21 @@ func (s *String) Match(re string) *Match {
 	r, err := regexp.Compile(re)
 	if err != nil {
 		s.chain.fail(err.Error())
-		return makeMatch(s.chain, nil, nil)
+		return makeMatch(s.chain.clone(), nil, nil)
 	}
 
 	m := r.FindStringSubmatch(s.value)
 	if m == nil {
-		s.chain.fail("\nexpected string matching regexp:\n `%s`\n\nbut got:\n %q",
-			re, s.value)
-		return makeMatch(s.chain, nil, nil)
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string matching regexp",
+			expected:   fmt.Sprintf(" `%s`", re),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
+		return makeMatch(s.chain.clone(), nil, nil)
 	}
 
-	return makeMatch(s.chain, m, r.SubexpNames())
+	return makeMatch(s.chain.clone(), m, r.SubexpNames())
 }
 
 // MatchAll find all matches in string for given regexp and returns a list
@@ -279,15 +652,19 @@ func (s *String) MatchAll(re string) []Match {
 
 	matches := r.FindAllStringSubmatch(s.value, -1)
 	if matches == nil {
-		s.chain.fail("\nexpected string matching regexp:\n `%s`\n\nbut got:\n %q",
-			re, s.value)
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string matching regexp",
+			expected:   fmt.Sprintf(" `%s`", re),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
 		return []Match{}
 	}
 
 	ret := []Match{}
 	for _, m := range matches {
 		ret = append(ret, *makeMatch(
-			s.chain,
+			s.chain.clone(),
 			m,
 			r.SubexpNames()))
 	}
@@ -311,10 +688,43 @@ func (s *String) NotMatch(re string) *String {
 	}
 
 	if r.MatchString(s.value) {
-		s.chain.fail("\nexpected string not matching regexp:\n `%s`\n\nbut got:\n %q",
-			re, s.value)
+		s.chain.failWith(failure{
+			assertType: "string",
+			assertion:  "expected string not matching regexp",
+			expected:   fmt.Sprintf(" `%s`", re),
+			actual:     fmt.Sprintf(" %q", s.value),
+		})
 		return s
 	}
 
 	return s
 }
+
+// containsFold reports whether sub is within s under simple Unicode
+// case-folding, as defined by strings.EqualFold.
+func containsFold(s, sub string) bool {
+	n := utf8.RuneCountInString(sub)
+
+	for i := 0; i <= len(s); {
+		j := i
+		for k := 0; k < n; k++ {
+			if j >= len(s) {
+				return false
+			}
+			_, size := utf8.DecodeRuneInString(s[j:])
+			j += size
+		}
+
+		if strings.EqualFold(s[i:j], sub) {
+			return true
+		}
+
+		if i == len(s) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+
+	return false
+}
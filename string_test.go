@@ -17,17 +17,33 @@ func TestStringFailed(t *testing.T) {
 	value.Path("$").chain.assertFailed(t)
 	value.Schema("")
 
+	value.Length().chain.assertFailed(t)
+	value.ByteLength().chain.assertFailed(t)
+
 	value.DateTime()
+	value.AsBoolean()
+	value.AsNumber()
+	value.Base64Decode()
+	value.Base64DecodeRaw()
+	value.DecodeJSON()
+	value.Split("")
+	value.Trim()
+	value.TrimPrefix("")
+	value.TrimSuffix("")
+	value.Unquote().chain.assertFailed(t)
 	value.Empty()
 	value.NotEmpty()
 	value.Equal("")
 	value.NotEqual("")
+	value.EqualToAnyOf("")
 	value.EqualFold("")
 	value.NotEqualFold("")
 	value.Contains("")
 	value.NotContains("")
 	value.ContainsFold("")
 	value.NotContainsFold("")
+	value.LengthMin(0)
+	value.LengthMax(0)
 }
 
 func TestStringGetters(t *testing.T) {
@@ -100,6 +116,28 @@ func TestStringEqual(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestStringEqualToAnyOf(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewString(reporter, "active")
+
+	value.EqualToAnyOf("active")
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EqualToAnyOf("pending", "active")
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.EqualToAnyOf("pending", "ACTIVE", "")
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.EqualToAnyOf()
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
 func TestStringEqualFold(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -182,6 +220,47 @@ func TestStringContainsFold(t *testing.T) {
 	value.chain.reset()
 }
 
+func TestStringContainsFoldUnicode(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		str      string
+		sub      string
+		contains bool
+	}{
+		{"", "", true},
+		{"foo", "", true},
+		{"", "foo", false},
+		{"ПРИВЕТ, мир", "привет", true},
+		{"ПРИВЕТ, мир", "МИР", true},
+		{"ПРИВЕТ, мир", "пока", false},
+		{"100 \u212a", "100 k", true},
+		{"Me\u017fsage", "MESSAGE", true},
+		{"Straße", "STRASSE", false},
+		{"xyz", "XYZW", false},
+	}
+
+	for _, tc := range cases {
+		value := NewString(reporter, tc.str)
+
+		value.ContainsFold(tc.sub)
+		if tc.contains {
+			value.chain.assertOK(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.reset()
+
+		value.NotContainsFold(tc.sub)
+		if tc.contains {
+			value.chain.assertFailed(t)
+		} else {
+			value.chain.assertOK(t)
+		}
+		value.chain.reset()
+	}
+}
+
 func TestStringLength(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -191,6 +270,55 @@ func TestStringLength(t *testing.T) {
 	value.chain.assertOK(t)
 	num.chain.assertOK(t)
 	assert.Equal(t, 7.0, num.Raw())
+
+	num = value.ByteLength()
+	value.chain.assertOK(t)
+	num.chain.assertOK(t)
+	assert.Equal(t, 7.0, num.Raw())
+}
+
+func TestStringLengthUnicode(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewString(reporter, "Привет, 世界")
+
+	assert.Equal(t, 10.0, value.Length().Raw())
+	assert.Equal(t, 20.0, value.ByteLength().Raw())
+	value.chain.assertOK(t)
+}
+
+func TestStringLengthMinMax(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewString(reporter, "Привет")
+
+	value.LengthMin(6)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.LengthMin(0)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.LengthMin(7)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.LengthMax(6)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.LengthMax(12)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	value.LengthMax(5)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.LengthMin(5).LengthMax(7)
+	value.chain.assertOK(t)
+	value.chain.reset()
 }
 
 func TestStringDateTime(t *testing.T) {
@@ -213,6 +341,286 @@ func TestStringDateTime(t *testing.T) {
 	value3.chain.assertFailed(t)
 	dt3.chain.assertFailed(t)
 	assert.True(t, time.Unix(0, 0).Equal(dt3.Raw()))
+
+	value4 := NewString(reporter, "1994-11-15T08:12:31Z")
+	dt4 := value4.DateTime()
+	value4.chain.assertOK(t)
+	dt4.chain.assertOK(t)
+	assert.True(t, time.Date(1994, 11, 15, 8, 12, 31, 0, time.UTC).Equal(dt4.Raw()))
+
+	value5 := NewString(reporter, "1994-11-15T10:12:31.5+02:00")
+	dt5 := value5.DateTime()
+	value5.chain.assertOK(t)
+	dt5.chain.assertOK(t)
+	assert.True(t,
+		time.Date(1994, 11, 15, 8, 12, 31, 500000000, time.UTC).Equal(dt5.Raw()))
+
+	value6 := NewString(reporter, "Tuesday, 15-Nov-94 08:12:31 GMT")
+	dt6 := value6.DateTime()
+	value6.chain.assertOK(t)
+	dt6.chain.assertOK(t)
+	assert.True(t, time.Date(1994, 11, 15, 8, 12, 31, 0, time.UTC).Equal(dt6.Raw()))
+
+	value7 := NewString(reporter, "1994-11-15")
+	dt7 := value7.DateTime()
+	value7.chain.assertFailed(t)
+	dt7.chain.assertFailed(t)
+
+	value8 := NewString(reporter, "1994-11-15T08:12:31Z")
+	dt8 := value8.DateTime(time.RFC1123)
+	value8.chain.assertFailed(t)
+	dt8.chain.assertFailed(t)
+}
+
+func TestStringAsBoolean(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	trueValues := []string{
+		"1", "t", "T", "TRUE", "true", "True", "yes", "Yes", "YES",
+	}
+	falseValues := []string{
+		"0", "f", "F", "FALSE", "false", "False", "no", "No", "NO",
+	}
+
+	for _, str := range trueValues {
+		value := NewString(reporter, str)
+		b := value.AsBoolean()
+		value.chain.assertOK(t)
+		b.chain.assertOK(t)
+		assert.True(t, b.Raw())
+	}
+
+	for _, str := range falseValues {
+		value := NewString(reporter, str)
+		b := value.AsBoolean()
+		value.chain.assertOK(t)
+		b.chain.assertOK(t)
+		assert.False(t, b.Raw())
+	}
+
+	for _, str := range []string{"", "on", "off", "truee", "yEs", " true", "y", "n"} {
+		value := NewString(reporter, str)
+		b := value.AsBoolean()
+		value.chain.assertFailed(t)
+		b.chain.assertFailed(t)
+		assert.False(t, b.Raw())
+	}
+}
+
+func TestStringAsNumber(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		str    string
+		base   []int
+		number float64
+	}{
+		{"42", nil, 42},
+		{"-3.14", nil, -3.14},
+		{"1e3", nil, 1000},
+		{"42", []int{10}, 42},
+		{"-101", []int{2}, -5},
+		{"ff", []int{16}, 255},
+		{"0x1F", []int{0}, 31},
+	}
+
+	for _, tc := range cases {
+		value := NewString(reporter, tc.str)
+		num := value.AsNumber(tc.base...)
+		value.chain.assertOK(t)
+		num.chain.assertOK(t)
+		assert.Equal(t, tc.number, num.Raw())
+	}
+
+	failures := []struct {
+		str  string
+		base []int
+	}{
+		{"", nil},
+		{"abc", nil},
+		{"1.5", []int{10}},
+		{"ff", []int{10}},
+		{"12", []int{1}},
+		{"12", []int{10, 16}},
+	}
+
+	for _, tc := range failures {
+		value := NewString(reporter, tc.str)
+		num := value.AsNumber(tc.base...)
+		value.chain.assertFailed(t)
+		num.chain.assertFailed(t)
+		assert.Equal(t, 0.0, num.Raw())
+	}
+}
+
+func TestStringBase64Decode(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value1 := NewString(reporter, "SGVsbG8sIFdvcmxkIQ==")
+	value1.Base64Decode().Equal("Hello, World!").chain.assertOK(t)
+	value1.chain.assertOK(t)
+
+	value2 := NewString(reporter, "-_8")
+	value2.Base64DecodeRaw().Equal("\xfb\xff").chain.assertOK(t)
+	value2.chain.assertOK(t)
+
+	value3 := NewString(reporter, "eyJmb28iOiJiYXIifQ")
+	value3.Base64DecodeRaw().Equal(`{"foo":"bar"}`).chain.assertOK(t)
+	value3.chain.assertOK(t)
+
+	value4 := NewString(reporter, "")
+	value4.Base64Decode().Empty().chain.assertOK(t)
+	value4.Base64DecodeRaw().Empty().chain.assertOK(t)
+	value4.chain.assertOK(t)
+
+	for _, str := range []string{"SGVsbG8", "-_8=", "not base64!"} {
+		value := NewString(reporter, str)
+		decoded := value.Base64Decode()
+		value.chain.assertFailed(t)
+		decoded.chain.assertFailed(t)
+		assert.Equal(t, "", decoded.Raw())
+	}
+
+	for _, str := range []string{"SGVsbG8=", "+/8", "not base64!"} {
+		value := NewString(reporter, str)
+		decoded := value.Base64DecodeRaw()
+		value.chain.assertFailed(t)
+		decoded.chain.assertFailed(t)
+		assert.Equal(t, "", decoded.Raw())
+	}
+}
+
+func TestStringDecodeJSON(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value1 := NewString(reporter, `{"foo": [1, "bar", null], "baz": true}`)
+	json1 := value1.DecodeJSON()
+	value1.chain.assertOK(t)
+	json1.chain.assertOK(t)
+	assert.Equal(t, map[string]interface{}{
+		"foo": []interface{}{1.0, "bar", nil},
+		"baz": true,
+	}, json1.Raw())
+
+	value2 := NewString(reporter, `"str"`)
+	value2.DecodeJSON().String().Equal("str").chain.assertOK(t)
+	value2.chain.assertOK(t)
+
+	value3 := NewString(reporter, `null`)
+	value3.DecodeJSON().Null().chain.assertOK(t)
+	value3.chain.assertOK(t)
+
+	for _, str := range []string{"", "{", `{"foo": 1} x`, "foo"} {
+		value := NewString(reporter, str)
+		decoded := value.DecodeJSON()
+		value.chain.assertFailed(t)
+		decoded.chain.assertFailed(t)
+		assert.Nil(t, decoded.Raw())
+	}
+}
+
+func TestStringDecodeJSONPreserveNumbers(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewString(reporter, `{"id": 9007199254740993}`)
+	value.chain.preserveNumbers = true
+
+	id := value.DecodeJSON().Object().Value("id").Number()
+	id.chain.assertOK(t)
+
+	id.NotEqual(9007199254740992)
+	id.chain.assertOK(t)
+	id.chain.reset()
+
+	id.Equal(uint64(9007199254740993))
+	id.chain.assertOK(t)
+	id.chain.reset()
+}
+
+func TestStringSplit(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		str   string
+		sep   string
+		parts []interface{}
+	}{
+		{"foo,bar,baz", ",", []interface{}{"foo", "bar", "baz"}},
+		{"foo", ",", []interface{}{"foo"}},
+		{"", ",", []interface{}{""}},
+		{",foo,", ",", []interface{}{"", "foo", ""}},
+		{"a::b", "::", []interface{}{"a", "b"}},
+		{"abc", "", []interface{}{"a", "b", "c"}},
+	}
+
+	for _, tc := range cases {
+		value := NewString(reporter, tc.str)
+		parts := value.Split(tc.sep)
+		value.chain.assertOK(t)
+		parts.chain.assertOK(t)
+		assert.Equal(t, tc.parts, parts.Raw())
+	}
+
+	value := NewString(reporter, "/users/42")
+	value.Split("/").Length().Equal(3).chain.assertOK(t)
+	value.Split("/").Last().String().Equal("42").chain.assertOK(t)
+}
+
+func TestStringTrim(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewString(reporter, " \t foo bar \r\n")
+
+	value.Trim().Equal("foo bar").chain.assertOK(t)
+	value.Equal(" \t foo bar \r\n").chain.assertOK(t)
+
+	NewString(reporter, "").Trim().Empty().chain.assertOK(t)
+	NewString(reporter, " \u00a0 ").Trim().Empty().chain.assertOK(t)
+	NewString(reporter, "foo").Trim().Equal("foo").chain.assertOK(t)
+}
+
+func TestStringTrimPrefixSuffix(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewString(reporter, "prefix-foo-suffix")
+
+	value.TrimPrefix("prefix-").Equal("foo-suffix").chain.assertOK(t)
+	value.TrimPrefix("foo").Equal("prefix-foo-suffix").chain.assertOK(t)
+	value.TrimPrefix("").Equal("prefix-foo-suffix").chain.assertOK(t)
+
+	value.TrimSuffix("-suffix").Equal("prefix-foo").chain.assertOK(t)
+	value.TrimSuffix("foo").Equal("prefix-foo-suffix").chain.assertOK(t)
+	value.TrimSuffix("").Equal("prefix-foo-suffix").chain.assertOK(t)
+
+	value.TrimPrefix("prefix-").TrimSuffix("-suffix").Equal("foo").
+		chain.assertOK(t)
+
+	value.Equal("prefix-foo-suffix").chain.assertOK(t)
+}
+
+func TestStringUnquote(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value1 := NewString(reporter, `"33a64df551425fcc55e4d42a148795d9f25f89d4"`)
+	value1.Unquote().Equal("33a64df551425fcc55e4d42a148795d9f25f89d4").
+		chain.assertOK(t)
+	value1.chain.assertOK(t)
+
+	value2 := NewString(reporter, `"foo \"bar\"\tbaz \u00e9"`)
+	value2.Unquote().Equal("foo \"bar\"\tbaz \u00e9").chain.assertOK(t)
+	value2.chain.assertOK(t)
+
+	value3 := NewString(reporter, `""`)
+	value3.Unquote().Empty().chain.assertOK(t)
+	value3.chain.assertOK(t)
+
+	for _, str := range []string{"foo", `"foo`, `foo"`, "`foo`", "'f'", `"\x"`, ""} {
+		value := NewString(reporter, str)
+		unquoted := value.Unquote()
+		value.chain.assertFailed(t)
+		unquoted.chain.assertFailed(t)
+		assert.Equal(t, str, unquoted.Raw())
+	}
 }
 
 func TestStringMatchOne(t *testing.T) {
@@ -1,7 +1,8 @@
 package httpexpect
 
 import (
-	"reflect"
+	"encoding/json"
+	"fmt"
 )
 
 // Value provides methods to inspect attached interface{} object
@@ -61,7 +62,8 @@ func (v *Value) Raw() interface{} {
 //
 // We currently use https://github.com/yalp/jsonpath, which implements
 // only a subset of JSONPath, yet useful for simple queries. It doesn't
-// support filters and requires double quotes for strings.
+// support filters and requires double quotes for strings. See JSONPath
+// if you need filters.
 //
 // Example 1:
 //  json := `{"users": [{"name": "john"}, {"name": "bob"}]}`
@@ -81,6 +83,59 @@ func (v *Value) Path(path string) *Value {
 	return getPath(&v.chain, v.value, path)
 }
 
+// JSONPath is similar to Path, but additionally supports filter expressions.
+//
+// Filter has form "[?(@.key <op> <literal>)]" or "[?(@.key)]", where op is
+// one of ==, !=, <, <=, >, >=, and literal is a number, a string in single
+// or double quotes, true, false, or null. "[?(@.key)]" matches elements
+// that have given key; elements without the key never match other filters.
+// Filters may be applied to arrays and objects, and
+// always produce an array of matches, which may be empty.
+//
+// Other expressions, like wildcards, are handled in the same way as in Path.
+//
+// Example:
+//  json := `{"users": [{"name": "john", "active": true}, {"name": "bob"}]}`
+//  value := NewValue(t, json)
+//
+//  value.JSONPath("$.users[*].name").Array().Elements("john", "bob")
+//  value.JSONPath("$.users[?(@.active == true)].name").Array().Elements("john")
+func (v *Value) JSONPath(expr string) *Value {
+	return getJSONPath(&v.chain, v.value, expr)
+}
+
+// PathGJSON returns a new Value object for child object(s) matching given
+// path in gjson syntax. See https://github.com/tidwall/gjson.
+//
+// Supported syntax:
+//  - "a.b.c" - dot-separated keys; "\." escapes a dot inside key
+//  - "a.0" - array element by index
+//  - "a.#" - array length
+//  - "a.#.b" - key "b" of every array element, as an array
+//  - "a.#(b==1)" - first array element matching query
+//  - "a.#(b==1)#" - all array elements matching query, as an array
+//  - "a.b*", "a.b?" - first key matching wildcard, in lexicographical order
+//
+// Queries support ==, !=, <, <=, >, >=, % (wildcard match) and !% operators.
+// The value should be a JSON literal, e.g. 1, "str", or true. Query with
+// just a path, like "#(b)", matches elements that have given path.
+//
+// If path can't be resolved, failure is reported, including the segment
+// that failed, and empty (but non-nil) value is returned.
+//
+// Example:
+//  json := `{"users": [{"name": "john", "age": 30}, {"name": "bob", "age": 20}]}`
+//  value := NewValue(t, json)
+//
+//  value.PathGJSON("users.#").Number().Equal(2)
+//  value.PathGJSON("users.1.name").String().Equal("bob")
+//  value.PathGJSON("users.#.name").Array().Elements("john", "bob")
+//  value.PathGJSON(`users.#(name=="bob").age`).Number().Equal(20)
+//  value.PathGJSON("users.#(age>25)#.name").Array().Elements("john")
+func (v *Value) PathGJSON(path string) *Value {
+	return getGJSONPath(&v.chain, v.value, path)
+}
+
 // Schema succeeds if value matches given JSON Schema.
 //
 // JSON Schema specifies a JSON-based format to define the structure of
@@ -118,7 +173,32 @@ func (v *Value) Path(path string) *Value {
 //  value := NewValue(t, data)
 //  value.Schema("http://example.com/schema.json")
 func (v *Value) Schema(schema interface{}) *Value {
-	checkSchema(&v.chain, v.value, schema)
+	checkSchema(&v.chain, "value", v.value, schema)
+	return v
+}
+
+// Decode unmarshals the underlying value into target.
+//
+// target should be a non-nil pointer. The underlying value is marshaled
+// to JSON and then unmarshaled into target using encoding/json, so json
+// struct tags are honored. If target is not a non-nil pointer or if the
+// value can't be unmarshaled into it, failure is reported.
+//
+// Example:
+//  type User struct {
+//      Name string `json:"name"`
+//  }
+//
+//  var user User
+//  value := NewValue(t, map[string]interface{}{"name": "john"})
+//  value.Decode(&user)
+//
+//  assert.Equal(t, "john", user.Name)
+func (v *Value) Decode(target interface{}) *Value {
+	if v.chain.failed() {
+		return v
+	}
+	canonDecode(&v.chain, v.value, target)
 	return v
 }
 
@@ -133,10 +213,14 @@ func (v *Value) Schema(schema interface{}) *Value {
 func (v *Value) Object() *Object {
 	data, ok := v.value.(map[string]interface{})
 	if !ok {
-		v.chain.fail("\nexpected object value (map or struct), but got:\n%s",
-			dumpValue(v.value))
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected object value",
+			got:        fmt.Sprintf("but got %s", jsonType(v.value)),
+			actual:     dumpValue(v.value),
+		})
 	}
-	return &Object{v.chain, data}
+	return &Object{v.chain.clone(), data}
 }
 
 // Array returns a new Array attached to underlying value.
@@ -150,10 +234,14 @@ func (v *Value) Object() *Object {
 func (v *Value) Array() *Array {
 	data, ok := v.value.([]interface{})
 	if !ok {
-		v.chain.fail("\nexpected array value, but got:\n%s",
-			dumpValue(v.value))
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected array value",
+			got:        fmt.Sprintf("but got %s", jsonType(v.value)),
+			actual:     dumpValue(v.value),
+		})
 	}
-	return &Array{v.chain, data}
+	return &Array{v.chain.clone(), data}
 }
 
 // String returns a new String attached to underlying value.
@@ -167,10 +255,14 @@ func (v *Value) Array() *Array {
 func (v *Value) String() *String {
 	data, ok := v.value.(string)
 	if !ok {
-		v.chain.fail("\nexpected string value, but got:\n%s",
-			dumpValue(v.value))
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected string value",
+			got:        fmt.Sprintf("but got %s", jsonType(v.value)),
+			actual:     dumpValue(v.value),
+		})
 	}
-	return &String{v.chain, data}
+	return &String{v.chain.clone(), data}
 }
 
 // Number returns a new Number attached to underlying value.
@@ -182,12 +274,21 @@ func (v *Value) String() *String {
 //  value := NewValue(t, 123)
 //  value.Number().InRange(100, 200)
 func (v *Value) Number() *Number {
+	if number, ok := v.value.(json.Number); ok {
+		data, _ := numberFloat(number)
+		exact, _ := exactInteger(number)
+		return &Number{v.chain.clone(), data, exact}
+	}
 	data, ok := v.value.(float64)
 	if !ok {
-		v.chain.fail("\nexpected numeric value, but got:\n%s",
-			dumpValue(v.value))
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected numeric value",
+			got:        fmt.Sprintf("but got %s", jsonType(v.value)),
+			actual:     dumpValue(v.value),
+		})
 	}
-	return &Number{v.chain, data}
+	return &Number{v.chain.clone(), data, nil}
 }
 
 // Boolean returns a new Boolean attached to underlying value.
@@ -201,10 +302,14 @@ func (v *Value) Number() *Number {
 func (v *Value) Boolean() *Boolean {
 	data, ok := v.value.(bool)
 	if !ok {
-		v.chain.fail("\nexpected boolean value, but got:\n%s",
-			dumpValue(v.value))
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected boolean value",
+			got:        fmt.Sprintf("but got %s", jsonType(v.value)),
+			actual:     dumpValue(v.value),
+		})
 	}
-	return &Boolean{v.chain, data}
+	return &Boolean{v.chain.clone(), data}
 }
 
 // Null succeeds if value is nil.
@@ -221,12 +326,29 @@ func (v *Value) Boolean() *Boolean {
 //  value.Null()
 func (v *Value) Null() *Value {
 	if v.value != nil {
-		v.chain.fail("\nexpected nil value, but got:\n%s",
-			dumpValue(v.value))
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected nil value",
+			actual:     dumpValue(v.value),
+		})
 	}
 	return v
 }
 
+// IsNull is an alias for Null.
+//
+// Note that for a value retrieved from an object, IsNull checks that the
+// key is present and holds JSON null; if the key is missing, Object.Value
+// reports failure before IsNull is reached.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": nil})
+//  object.Value("foo").IsNull()  // success
+//  object.Value("bar").IsNull()  // failure (missing key)
+func (v *Value) IsNull() *Value {
+	return v.Null()
+}
+
 // NotNull succeeds if value is not nil.
 //
 // Note that non-nil interface{} that points to nil value (e.g. nil slice or map)
@@ -237,12 +359,51 @@ func (v *Value) Null() *Value {
 //  value := NewValue(t, "")
 //  value.NotNull()
 //
-//  value := NewValue(t, make([]interface{}, 0)
-//  value.Null()
+//  value := NewValue(t, make([]interface{}, 0))
+//  value.NotNull()
 func (v *Value) NotNull() *Value {
 	if v.value == nil {
-		v.chain.fail("\nexpected non-nil value, but got:\n%s",
-			dumpValue(v.value))
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected non-nil value",
+			actual:     dumpValue(v.value),
+		})
+	}
+	return v
+}
+
+// IsType succeeds if value has given JSON type.
+//
+// kind should be one of "object", "array", "string", "number", "boolean",
+// or "null". If value has another type, failure is reported with the
+// actual type. This is useful to check that value has expected type before
+// inspecting it further.
+//
+// Example:
+//  object := NewObject(t, map[string]interface{}{"foo": map[string]interface{}{}})
+//  object.Value("foo").IsType("object")
+func (v *Value) IsType(kind string) *Value {
+	if v.chain.failed() {
+		return v
+	}
+	switch kind {
+	case "object", "array", "string", "number", "boolean", "null":
+	default:
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion: "expected JSON type name (object, array, string, number," +
+				" boolean, or null)",
+			actual: fmt.Sprintf(" %q", kind),
+		})
+		return v
+	}
+	if actual := jsonType(v.value); actual != kind {
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  fmt.Sprintf("expected %s value", kind),
+			got:        fmt.Sprintf("but got %s", actual),
+			actual:     dumpValue(v.value),
+		})
 	}
 	return v
 }
@@ -258,11 +419,15 @@ func (v *Value) Equal(value interface{}) *Value {
 	if !ok {
 		return v
 	}
-	if !reflect.DeepEqual(expected, v.value) {
-		v.chain.fail("\nexpected value equal to:\n%s\n\nbut got:\n%s\n\ndiff:\n%s",
-			dumpValue(expected),
-			dumpValue(v.value),
-			diffValues(expected, v.value))
+	expected = markTimes(value, expected)
+	if !v.chain.equal(expected, v.value) {
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected value equal to",
+			expected:   dumpValue(expected),
+			actual:     dumpValue(v.value),
+			details:    "\n\ndiff:\n" + diffValues(expected, v.value),
+		})
 	}
 	return v
 }
@@ -278,9 +443,13 @@ func (v *Value) NotEqual(value interface{}) *Value {
 	if !ok {
 		return v
 	}
-	if reflect.DeepEqual(expected, v.value) {
-		v.chain.fail("\nexpected value not equal to:\n%s",
-			dumpValue(expected))
+	expected = markTimes(value, expected)
+	if v.chain.equal(expected, v.value) {
+		v.chain.failWith(failure{
+			assertType: "value",
+			assertion:  "expected value not equal to",
+			expected:   dumpValue(expected),
+		})
 	}
 	return v
 }
@@ -20,7 +20,10 @@ func TestValueFailed(t *testing.T) {
 	value.chain.assertFailed(t)
 
 	value.Path("$").chain.assertFailed(t)
+	value.JSONPath("$").chain.assertFailed(t)
+	value.PathGJSON("a").chain.assertFailed(t)
 	value.Schema("")
+	value.Decode(&struct{}{})
 
 	assert.False(t, value.Object() == nil)
 	assert.False(t, value.Array() == nil)
@@ -36,12 +39,134 @@ func TestValueFailed(t *testing.T) {
 	value.Boolean().chain.assertFailed(t)
 
 	value.Null()
+	value.IsNull()
 	value.NotNull()
+	value.IsType("null").chain.assertFailed(t)
 
 	value.Equal(nil)
 	value.NotEqual(nil)
 }
 
+func TestValueIsType(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	values := map[string]interface{}{
+		"object":  map[string]interface{}{},
+		"array":   []interface{}{},
+		"string":  "",
+		"number":  0,
+		"boolean": false,
+		"null":    nil,
+	}
+
+	for kind, data := range values {
+		for otherKind := range values {
+			value := NewValue(reporter, data)
+			value.IsType(otherKind)
+			if otherKind == kind {
+				value.chain.assertOK(t)
+			} else {
+				value.chain.assertFailed(t)
+				assert.Contains(t, reporter.message, "but got "+kind)
+			}
+		}
+	}
+
+	value := NewValue(reporter, "foo")
+	value.IsType("integer")
+	value.chain.assertFailed(t)
+	assert.Contains(t, reporter.message, `"integer"`)
+
+	object := NewObject(reporter, map[string]interface{}{
+		"foo": map[string]interface{}{"bar": 123},
+	})
+	object.Value("foo").IsType("object").Object().ContainsKey("bar").
+		chain.assertOK(t)
+	object.Value("foo").IsType("array").chain.assertFailed(t)
+}
+
+func TestValueDecode(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	type User struct {
+		Name  string   `json:"name"`
+		Age   int      `json:"age"`
+		Tags  []string `json:"tags"`
+		Admin bool
+	}
+
+	value := NewValue(reporter, map[string]interface{}{
+		"name":  "john",
+		"age":   42,
+		"tags":  []interface{}{"a", "b"},
+		"Admin": true,
+	})
+
+	var user User
+	value.Decode(&user)
+	value.chain.assertOK(t)
+	value.chain.reset()
+
+	assert.Equal(t, User{Name: "john", Age: 42, Tags: []string{"a", "b"}, Admin: true},
+		user)
+
+	var m map[string]interface{}
+	value.Path("$.tags").Decode(&m).chain.assertFailed(t)
+
+	var tags []string
+	value.Path("$.tags").Decode(&tags).chain.assertOK(t)
+	assert.Equal(t, []string{"a", "b"}, tags)
+
+	var age float64
+	value.Path("$.age").Decode(&age).chain.assertOK(t)
+	assert.Equal(t, 42.0, age)
+
+	var iface interface{}
+	value.Decode(&iface)
+	value.chain.assertOK(t)
+	value.chain.reset()
+	assert.Equal(t, value.Raw(), iface)
+
+	value.Decode(nil)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	value.Decode(user)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	var nilUser *User
+	value.Decode(nilUser)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+
+	var wrong struct {
+		Name int `json:"name"`
+	}
+	value.Decode(&wrong)
+	value.chain.assertFailed(t)
+	value.chain.reset()
+}
+
+func TestValueCastMessages(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	NewValue(reporter, map[string]interface{}{}).Array().chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "expected array value, but got object:")
+
+	NewValue(reporter, []interface{}{}).Object().chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "expected object value, but got array:")
+
+	NewValue(reporter, 123).String().chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "expected string value, but got number:")
+
+	NewValue(reporter, "123").Number().chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "expected numeric value, but got string:")
+
+	NewValue(reporter, nil).Boolean().chain.assertFailed(t)
+	assert.Contains(t, reporter.message, "expected boolean value, but got null:")
+}
+
 func TestValueCastNull(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -56,6 +181,33 @@ func TestValueCastNull(t *testing.T) {
 	NewValue(reporter, data).Null().chain.assertOK(t)
 }
 
+func TestValueIsNull(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	NewValue(reporter, nil).IsNull().chain.assertOK(t)
+	NewValue(reporter, "").IsNull().chain.assertFailed(t)
+
+	object := NewObject(reporter, map[string]interface{}{
+		"foo": nil,
+		"bar": []interface{}{nil, "baz"},
+	})
+
+	object.Value("foo").IsNull().chain.assertOK(t)
+	object.Value("foo").NotNull().chain.assertFailed(t)
+
+	object.Value("missing").IsNull().chain.assertFailed(t)
+	object.chain.reset()
+
+	object.Value("missing").NotNull().chain.assertFailed(t)
+	object.chain.reset()
+
+	object.Value("bar").Array().Element(0).IsNull().chain.assertOK(t)
+	object.Value("bar").Array().Element(1).IsNull().chain.assertFailed(t)
+
+	object.Path("$.foo").IsNull().chain.assertOK(t)
+	object.Path("$.bar").IsNull().chain.assertFailed(t)
+}
+
 func TestValueCastIndirectNull(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -334,6 +486,184 @@ func TestValuePathObject(t *testing.T) {
 	}
 }
 
+func TestValueJSONPath(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	user0 := map[string]interface{}{"name": "john", "age": 30.0, "active": true}
+	user1 := map[string]interface{}{"name": "bob", "age": 20.0}
+	user2 := map[string]interface{}{"name": "o'neil", "age": 40.0, "active": false}
+
+	data := map[string]interface{}{
+		"users": []interface{}{user0, user1, user2},
+		"groups": map[string]interface{}{
+			"admins": map[string]interface{}{"size": 1.0},
+			"guests": map[string]interface{}{"size": 5.0},
+		},
+	}
+
+	value := NewValue(reporter, data)
+
+	cases := []struct {
+		expr     string
+		expected interface{}
+	}{
+		{"$.users[0].name", "john"},
+		{"$.users[*].name", []interface{}{"john", "bob", "o'neil"}},
+		{"$.users[?(@.active == true)]", []interface{}{user0}},
+		{"$.users[?(@.active==true)].name", []interface{}{"john"}},
+		{"$.users[?(@.active)].name", []interface{}{"john", "o'neil"}},
+		{"$.users[?(@.active != true)].name", []interface{}{"o'neil"}},
+		{"$.users[?(@.age > 25)].name", []interface{}{"john", "o'neil"}},
+		{"$.users[?(@.age <= 30)].name", []interface{}{"john", "bob"}},
+		{"$.users[?(@.name == 'bob')].age", []interface{}{20.0}},
+		{`$.users[?(@.name == "o'neil")].age`, []interface{}{40.0}},
+		{"$.users[?(@.name > 'c')].name", []interface{}{"john", "o'neil"}},
+		{"$.users[?(@.missing == 1)]", []interface{}{}},
+		{"$.users[?(@.age > 10)].active", []interface{}{true, false}},
+		{"$.users[?(@.missing == 1)].nmae", []interface{}{}},
+		{"$.groups[?(@.size > 2)].size", []interface{}{5.0}},
+		{"$.users[?(@.age >= 30)][?(@.active == false)].name",
+			[]interface{}{}},
+	}
+
+	for _, tc := range cases {
+		result := value.JSONPath(tc.expr)
+		result.chain.assertOK(t)
+		assert.Equal(t, tc.expected, result.Raw(), tc.expr)
+	}
+	value.chain.assertOK(t)
+
+	nested := NewValue(reporter, map[string]interface{}{
+		"g": []interface{}{
+			map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"x": 1.0},
+					map[string]interface{}{"x": 2.0},
+				},
+			},
+			map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"x": 3.0},
+				},
+			},
+		},
+	})
+
+	for _, tc := range []struct {
+		expr     string
+		expected interface{}
+	}{
+		{"$.g[*].items[?(@.x > 1)].x", []interface{}{2.0, 3.0}},
+		{"$.g[*].items[?(@.x)].x", []interface{}{1.0, 2.0, 3.0}},
+		{"$.g[1].items[?(@.x > 1)].x", []interface{}{3.0}},
+		{"$.g[?(@.items)].items[0].x", []interface{}{1.0, 3.0}},
+	} {
+		result := nested.JSONPath(tc.expr)
+		result.chain.assertOK(t)
+		assert.Equal(t, tc.expected, result.Raw(), tc.expr)
+	}
+	nested.chain.assertOK(t)
+
+	for _, expr := range []string{
+		"$.bad",
+		"$.users[?(@.age > 1]",
+		"$.users[?(age > 1)]",
+		"$.users[?(@.age > [1])]",
+		"$.users[?(@.age > bad)]",
+		"$.users[?(@.age = 30)]",
+		"$.users[?(@.age =~ 30)]",
+		"$.users[?(@.age && @.name)]",
+		"$.users[?(@.age > 25)].nmae",
+	} {
+		bad := value.JSONPath(expr)
+		assert.True(t, bad != nil, expr)
+		assert.Nil(t, bad.Raw(), expr)
+		value.chain.assertFailed(t)
+		value.chain.reset()
+	}
+}
+
+func TestValuePathGJSON(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	user0 := map[string]interface{}{
+		"name": "john", "age": 30.0, "tags": []interface{}{"a"},
+	}
+	user1 := map[string]interface{}{"name": "bob", "age": 20.0}
+	user2 := map[string]interface{}{"name": "bobby", "age": 40.0}
+
+	data := map[string]interface{}{
+		"users":    []interface{}{user0, user1, user2},
+		"dot.key":  "dotted",
+		"settings": map[string]interface{}{"theme": "dark"},
+	}
+
+	value := NewValue(reporter, data)
+
+	cases := []struct {
+		path     string
+		expected interface{}
+	}{
+		{"users", data["users"]},
+		{"users.#", 3.0},
+		{"users.0", user0},
+		{"users.1.name", "bob"},
+		{"users.0.tags.0", "a"},
+		{"users.#.name", []interface{}{"john", "bob", "bobby"}},
+		{"users.#.tags", []interface{}{[]interface{}{"a"}}},
+		{`users.#(name=="bob")`, user1},
+		{`users.#(name=="bob").age`, 20.0},
+		{`users.#(name!="john").name`, "bob"},
+		{"users.#(age>25)#.name", []interface{}{"john", "bobby"}},
+		{"users.#(age<=20)#", []interface{}{user1}},
+		{`users.#(name%"bob*")#.age`, []interface{}{20.0, 40.0}},
+		{`users.#(name!%"bob*")#.age`, []interface{}{30.0}},
+		{"users.#(tags)#.name", []interface{}{"john"}},
+		{"users.#(age>100)#", []interface{}{}},
+		{`dot\.key`, "dotted"},
+		{"sett*.theme", "dark"},
+		{"settings.th?me", "dark"},
+	}
+
+	for _, tc := range cases {
+		result := value.PathGJSON(tc.path)
+		result.chain.assertOK(t)
+		assert.Equal(t, tc.expected, result.Raw(), tc.path)
+	}
+	value.chain.assertOK(t)
+
+	bad := []struct {
+		path    string
+		segment string
+	}{
+		{"missing", `"missing"`},
+		{"users.5", `"5"`},
+		{"users.name", `"name"`},
+		{"settings.theme.x", `"x"`},
+		{"settings.#", `"#"`},
+		{`users.#(name=="alice")`, `"#(name==\"alice\")"`},
+		{"users.#(age>)", `"#(age>)"`},
+	}
+
+	for _, tc := range bad {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, data)
+
+		result := value.PathGJSON(tc.path)
+		assert.True(t, result != nil, tc.path)
+		assert.Nil(t, result.Raw(), tc.path)
+		value.chain.assertFailed(t)
+		assert.Contains(t, reporter.message, tc.segment, tc.path)
+	}
+
+	for _, path := range []string{"", "users.#(age>1", "users)"} {
+		value.PathGJSON(path).chain.assertFailed(t)
+		value.chain.assertFailed(t)
+		value.chain.reset()
+	}
+}
+
 func TestValuePathArray(t *testing.T) {
 	reporter := newMockReporter(t)
 
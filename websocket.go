@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"encoding/json"
+	"net"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -25,7 +26,7 @@ type Websocket struct {
 // NewWebsocket returns a new Websocket given a Config with Reporter and
 // Printers, and websocket.Conn to be inspected and handled.
 func NewWebsocket(config Config, conn *websocket.Conn) *Websocket {
-	return makeWebsocket(config, makeChain(config.Reporter), conn)
+	return makeWebsocket(config, makeConfigChain(config), conn)
 }
 
 func makeWebsocket(config Config, chain chain, conn *websocket.Conn) *Websocket {
@@ -75,7 +76,7 @@ func (c *Websocket) WithoutWriteTimeout() *Websocket {
 // Subprotocol returns a new String object that may be used to inspect
 // negotiated protocol for the connection.
 func (c *Websocket) Subprotocol() *String {
-	s := &String{chain: c.chain}
+	s := &String{chain: c.chain.clone()}
 	if c.conn != nil {
 		s.value = c.conn.Subprotocol()
 	}
@@ -89,20 +90,39 @@ func (c *Websocket) Subprotocol() *String {
 //  msg := conn.Expect()
 //  msg.JSON().Object().ValueEqual("message", "hi")
 func (c *Websocket) Expect() *WebsocketMessage {
+	return c.readMessage(c.readTimeout)
+}
+
+// ExpectMessage is similar to Expect, but uses given timeout instead of
+// the one set by WithReadTimeout. Zero timeout means no timeout.
+//
+// If no message is received before timeout expires, failure is reported
+// and empty (but non-nil) object is returned. Note that after timeout,
+// the connection is left in a failed state and can't be read anymore.
+//
+// Example:
+//  msg := conn.ExpectMessage(time.Second)
+//  msg.JSON().Object().ValueEqual("message", "hi")
+func (c *Websocket) ExpectMessage(timeout time.Duration) *WebsocketMessage {
+	return c.readMessage(timeout)
+}
+
+func (c *Websocket) readMessage(timeout time.Duration) *WebsocketMessage {
 	switch {
 	case c.chain.failed():
-		return makeWebsocketMessage(c.chain)
+		return makeWebsocketMessage(c.chain.clone())
 	case c.conn == nil:
 		c.chain.fail("\nunexpected read from failed WebSocket connection")
-		return makeWebsocketMessage(c.chain)
+		return makeWebsocketMessage(c.chain.clone())
 	case c.isClosed:
 		c.chain.fail("\nunexpected read from closed WebSocket connection")
-		return makeWebsocketMessage(c.chain)
-	case !c.setReadDeadline():
-		return makeWebsocketMessage(c.chain)
+		return makeWebsocketMessage(c.chain.clone())
+	case !c.setReadDeadline(timeout):
+		return makeWebsocketMessage(c.chain.clone())
 	}
 	var err error
-	m := makeWebsocketMessage(c.chain)
+	m := makeWebsocketMessage(c.chain.clone())
+	m.decoder = c.config.JSONDecoder
 	m.typ, m.content, err = c.conn.ReadMessage()
 	if err != nil {
 		if cls, ok := err.(*websocket.CloseError); ok {
@@ -110,11 +130,15 @@ func (c *Websocket) Expect() *WebsocketMessage {
 			m.closeCode = cls.Code
 			m.content = []byte(cls.Text)
 			c.printRead(m.typ, m.content, m.closeCode)
+		} else if ne, ok := err.(net.Error); ok && ne.Timeout() {
+			c.chain.fail(
+				"\ntimed out waiting for WebSocket message after %s", timeout)
+			return makeWebsocketMessage(c.chain.clone())
 		} else {
 			c.chain.fail(
 				"\nexpected read WebSocket connection, "+
 					"but got failure: %s", err.Error())
-			return makeWebsocketMessage(c.chain)
+			return makeWebsocketMessage(c.chain.clone())
 		}
 	} else {
 		c.printRead(m.typ, m.content, m.closeCode)
@@ -122,10 +146,10 @@ func (c *Websocket) Expect() *WebsocketMessage {
 	return m
 }
 
-func (c *Websocket) setReadDeadline() bool {
+func (c *Websocket) setReadDeadline(timeout time.Duration) bool {
 	deadline := infiniteTime
-	if c.readTimeout != noDuration {
-		deadline = time.Now().Add(c.readTimeout)
+	if timeout != noDuration {
+		deadline = time.Now().Add(timeout)
 	}
 	if err := c.conn.SetReadDeadline(deadline); err != nil {
 		c.chain.fail(
@@ -1,14 +1,14 @@
 package httpexpect
 
 import (
-	"encoding/json"
-
+	"fmt"
 	"github.com/gorilla/websocket"
 )
 
 // WebsocketMessage provides methods to inspect message read from WebSocket connection.
 type WebsocketMessage struct {
 	chain     chain
+	decoder   JSONDecoder
 	typ       int
 	content   []byte
 	closeCode int
@@ -103,13 +103,19 @@ func (m *WebsocketMessage) Type(typ ...int) *WebsocketMessage {
 	}
 	if !yes {
 		if len(typ) > 1 {
-			m.chain.fail(
-				"\nexpected message type equal to one of:\n %v\n\nbut got:\n %d",
-				typ, m.typ)
+			m.chain.failWith(failure{
+				assertType: "message",
+				assertion:  "expected message type equal to one of",
+				expected:   fmt.Sprintf(" %v", typ),
+				actual:     fmt.Sprintf(" %d", m.typ),
+			})
 		} else {
-			m.chain.fail(
-				"\nexpected message type:\n %d\n\nbut got:\n %d",
-				typ[0], m.typ)
+			m.chain.failWith(failure{
+				assertType: "message",
+				assertion:  "expected message type",
+				expected:   fmt.Sprintf(" %d", typ[0]),
+				actual:     fmt.Sprintf(" %d", m.typ),
+			})
 		}
 	}
 	return m
@@ -134,13 +140,19 @@ func (m *WebsocketMessage) NotType(typ ...int) *WebsocketMessage {
 	for _, t := range typ {
 		if t == m.typ {
 			if len(typ) > 1 {
-				m.chain.fail(
-					"\nexpected message type not equal:\n %v\n\nbut got:\n %d",
-					typ, m.typ)
+				m.chain.failWith(failure{
+					assertType: "message",
+					assertion:  "expected message type not equal",
+					expected:   fmt.Sprintf(" %v", typ),
+					actual:     fmt.Sprintf(" %d", m.typ),
+				})
 			} else {
-				m.chain.fail(
-					"\nexpected message type not equal:\n %d\n\nbut it did",
-					typ[0], m.typ)
+				m.chain.failWith(failure{
+					assertType: "message",
+					assertion:  "expected message type not equal",
+					expected:   fmt.Sprintf(" %d", typ[0]),
+					details:    "\n\nbut it did",
+				})
 			}
 			return m
 		}
@@ -177,13 +189,19 @@ func (m *WebsocketMessage) Code(code ...int) *WebsocketMessage {
 	}
 	if !yes {
 		if len(code) > 1 {
-			m.chain.fail(
-				"\nexpected close code equal to one of:\n %v\n\nbut got:\n %d",
-				code, m.closeCode)
+			m.chain.failWith(failure{
+				assertType: "message",
+				assertion:  "expected close code equal to one of",
+				expected:   fmt.Sprintf(" %v", code),
+				actual:     fmt.Sprintf(" %d", m.closeCode),
+			})
 		} else {
-			m.chain.fail(
-				"\nexpected close code:\n %d\n\nbut got:\n %d",
-				code[0], m.closeCode)
+			m.chain.failWith(failure{
+				assertType: "message",
+				assertion:  "expected close code",
+				expected:   fmt.Sprintf(" %d", code[0]),
+				actual:     fmt.Sprintf(" %d", m.closeCode),
+			})
 		}
 	}
 	return m
@@ -212,13 +230,19 @@ func (m *WebsocketMessage) NotCode(code ...int) *WebsocketMessage {
 	for _, c := range code {
 		if c == m.closeCode {
 			if len(code) > 1 {
-				m.chain.fail(
-					"\nexpected close code not equal:\n %v\n\nbut got:\n %d",
-					code, m.closeCode)
+				m.chain.failWith(failure{
+					assertType: "message",
+					assertion:  "expected close code not equal",
+					expected:   fmt.Sprintf(" %v", code),
+					actual:     fmt.Sprintf(" %d", m.closeCode),
+				})
 			} else {
-				m.chain.fail(
-					"\nexpected close code not equal:\n %d\n\nbut it did",
-					code[0], m.closeCode)
+				m.chain.failWith(failure{
+					assertType: "message",
+					assertion:  "expected close code not equal",
+					expected:   fmt.Sprintf(" %d", code[0]),
+					details:    "\n\nbut it did",
+				})
 			}
 			return m
 		}
@@ -229,7 +253,7 @@ func (m *WebsocketMessage) NotCode(code ...int) *WebsocketMessage {
 func (m *WebsocketMessage) checkClosed(where string) bool {
 	if m.typ != websocket.CloseMessage {
 		m.chain.fail(
-			"\nunexpected %s usage for not '%' WebSocket message type\n\n"+
+			"\nunexpected %s usage for not '%s' WebSocket message type\n\n"+
 				"got type:\n %s",
 			where,
 			wsMessageTypeName(websocket.CloseMessage),
@@ -247,7 +271,7 @@ func (m *WebsocketMessage) checkClosed(where string) bool {
 //  msg.Body().NotEmpty()
 //  msg.Body().Length().Equal(100)
 func (m *WebsocketMessage) Body() *String {
-	return &String{m.chain, string(m.content)}
+	return &String{m.chain.clone(), string(m.content)}
 }
 
 // NoContent succeeds if WebSocket message has no content (is empty).
@@ -260,13 +284,17 @@ func (m *WebsocketMessage) NoContent() *WebsocketMessage {
 	}
 	switch m.typ {
 	case websocket.BinaryMessage:
-		m.chain.fail(
-			"\nexpected message body being empty, but got:\n %d bytes",
-			len(m.content))
+		m.chain.failWith(failure{
+			assertType: "message",
+			assertion:  "expected message body being empty",
+			actual:     fmt.Sprintf(" %d bytes", len(m.content)),
+		})
 	default:
-		m.chain.fail(
-			"\nexpected message body being empty, but got:\n %s",
-			string(m.content))
+		m.chain.failWith(failure{
+			assertType: "message",
+			assertion:  "expected message body being empty",
+			actual:     " " + string(m.content),
+		})
 	}
 	return m
 }
@@ -274,13 +302,15 @@ func (m *WebsocketMessage) NoContent() *WebsocketMessage {
 // JSON returns a new Value object that may be used to inspect JSON contents
 // of WebSocket message.
 //
-// JSON succeeds if JSON may be decoded from message content.
+// JSON succeeds if message is a text or binary message and JSON may be
+// decoded from message content.
 //
 // Example:
 //  msg := conn.Expect()
 //  msg.JSON().Array().Elements("foo", "bar")
 func (m *WebsocketMessage) JSON() *Value {
-	return &Value{m.chain, m.getJSON()}
+	value := m.getJSON()
+	return &Value{m.chain.clone(), value}
 }
 
 func (m *WebsocketMessage) getJSON() interface{} {
@@ -288,12 +318,17 @@ func (m *WebsocketMessage) getJSON() interface{} {
 		return nil
 	}
 
-	var value interface{}
-	if err := json.Unmarshal(m.content, &value); err != nil {
-		m.chain.fail(err.Error())
+	if m.typ != websocket.TextMessage && m.typ != websocket.BinaryMessage {
+		m.chain.failWith(failure{
+			assertType: "message",
+			assertion:  "expected text or binary message with JSON content",
+			actual:     " " + wsMessageTypeName(m.typ),
+		})
 		return nil
 	}
 
+	value, _ := decodeJSON(&m.chain, m.decoder, m.content)
+
 	return value
 }
 
@@ -340,6 +340,17 @@ func TestWebsocketMessageJSON(t *testing.T) {
 		require.Equal(t, "bar", j.Object().Value("foo").Raw())
 	})
 
+	t.Run("binary", func(t *testing.T) {
+		body := []byte(`[1, 2]`)
+
+		msg := NewWebsocketMessage(reporter, websocket.BinaryMessage, body)
+
+		j := msg.JSON()
+		j.chain.assertOK(t)
+
+		require.Equal(t, []interface{}{1.0, 2.0}, j.Array().Raw())
+	})
+
 	t.Run("bad", func(t *testing.T) {
 		body := []byte(`{`)
 
@@ -350,4 +361,22 @@ func TestWebsocketMessageJSON(t *testing.T) {
 
 		msg.chain.assertFailed(t)
 	})
+
+	t.Run("bad type", func(t *testing.T) {
+		for _, typ := range []int{
+			websocket.CloseMessage,
+			websocket.PingMessage,
+			websocket.PongMessage,
+		} {
+			body := []byte(`{"foo":"bar"}`)
+
+			msg := NewWebsocketMessage(reporter, typ, body)
+
+			j := msg.JSON()
+			j.chain.assertFailed(t)
+			require.Nil(t, j.Raw())
+
+			msg.chain.assertFailed(t)
+		}
+	})
 }
@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -25,6 +26,7 @@ func TestWebsocketFailed(t *testing.T) {
 
 	ws.Subprotocol().chain.assertFailed(t)
 	ws.Expect().chain.assertFailed(t)
+	ws.ExpectMessage(time.Second).chain.assertFailed(t)
 
 	ws.WriteMessage(websocket.TextMessage, []byte("a"))
 	ws.WriteBytesBinary([]byte("a"))
@@ -51,4 +53,7 @@ func TestWebsocketNil(t *testing.T) {
 	msg.chain.assertFailed(t)
 
 	ws.chain.assertFailed(t)
+
+	msg = NewWebsocket(config, nil).ExpectMessage(time.Second)
+	msg.chain.assertFailed(t)
 }